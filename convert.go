@@ -0,0 +1,434 @@
+package jodatime
+
+import (
+	"strings"
+	"time"
+)
+
+// A layoutToken is a layout element in Joda, Go and strftime layouts,
+// empty for a layout language that has no equivalent.
+type layoutToken struct {
+	std            int // canonical std value
+	joda, goLayout string
+	strftime       string
+}
+
+// layoutTokens are the layout elements that mean the same in Joda, Go and
+// strftime layouts.
+var layoutTokens = []layoutToken{
+	{stdLongMonth, "MMMM", "January", "%B"},
+	{stdMonth, "MMM", "Jan", "%b"},
+	{stdNumMonth | 1<<stdArgShift, "M", "1", "%-m"},
+	{stdNumMonth | 2<<stdArgShift, "MM", "01", "%m"},
+	{stdLongWeekDay, "EEEE", "Monday", "%A"},
+	{stdWeekDay, "EEE", "Mon", "%a"},
+	{stdDay | 1<<stdArgShift, "d", "2", "%-d"},
+	{stdDay | 2<<stdArgShift, "dd", "02", "%d"},
+	{stdDay | 2<<stdArgShift | stdSpacePad, "", "_2", "%e"},
+	{stdYearDay | 1<<stdArgShift, "D", "", "%-j"},
+	{stdYearDay | 3<<stdArgShift, "DDD", "002", "%j"},
+	{stdYearDay | 3<<stdArgShift | stdSpacePad, "", "__2", "%_j"},
+	{stdHour | 1<<stdArgShift, "H", "", "%-H"},
+	{stdHour | 2<<stdArgShift, "HH", "15", "%H"},
+	{stdHour | 2<<stdArgShift | stdSpacePad, "", "", "%k"},
+	{stdHour12 | 1<<stdArgShift, "h", "3", "%-I"},
+	{stdHour12 | 2<<stdArgShift, "hh", "03", "%I"},
+	{stdHour12 | 2<<stdArgShift | stdSpacePad, "", "", "%l"},
+	{stdMinute | 1<<stdArgShift, "m", "4", "%-M"},
+	{stdMinute | 2<<stdArgShift, "mm", "04", "%M"},
+	{stdSecond | 1<<stdArgShift, "s", "5", "%-S"},
+	{stdSecond | 2<<stdArgShift, "ss", "05", "%S"},
+	{stdLongYear | 4<<stdArgShift, "yyyy", "2006", "%Y"},
+	{stdYear | 2<<stdArgShift, "yy", "06", "%y"},
+	{stdWeekYear | 4<<stdArgShift, "xxxx", "", "%G"},
+	{stdWeekYear | 2<<stdArgShift, "xx", "", "%g"},
+	{stdWeek | 1<<stdArgShift, "w", "", "%-V"},
+	{stdWeek | 2<<stdArgShift, "ww", "", "%V"},
+	{stdISOWeekDay | 1<<stdArgShift, "e", "", "%u"},
+	{stdPM, "a", "PM", "%p"},
+	{stdpm, "", "pm", "%P"},
+	{stdTZ, "ZZZ", "MST", "%Z"},
+	{stdNumTZ, "Z", "-0700", "%z"},
+	{stdNumColonTZ, "ZZ", "-07:00", "%:z"},
+	{stdNumColonSecondsTZ, "", "-07:00:00", "%::z"},
+	{stdNumSecondsTz, "", "-070000", ""},
+	{stdNumShortTZ, "", "-07", ""},
+	// Joda prints a zero offset as a number, but parses "Z" too.
+	{stdISO8601TZ, "Z", "Z0700", ""},
+	{stdISO8601ColonTZ, "ZZ", "Z07:00", ""},
+	{stdISO8601SecondsTZ, "", "Z070000", ""},
+	{stdISO8601ColonSecondsTZ, "", "Z07:00:00", ""},
+	{stdISO8601ShortTZ, "", "Z07", ""},
+	{stdEpoch, "X", "", "%s"},
+	{stdEpoch | 3<<stdArgShift, "XXX", "", "%Q"},
+}
+
+// canonicalStd returns std without the flags and variants that do not
+// change how it is formatted.
+func canonicalStd(std int) int {
+	width := std >> stdArgShift & stdWidthMask
+	flags := std & (stdSpacePad | stdUSWeek)
+	switch std &= stdMask; std {
+	case stdZeroMonth:
+		std = stdNumMonth
+	case stdUnderDay, stdZeroDay:
+		std = stdDay
+	case stdZeroHour12:
+		std = stdHour12
+	case stdZeroMinute:
+		std = stdMinute
+	case stdZeroSecond:
+		std = stdSecond
+	case stdMonth, stdLongMonth, stdWeekDay, stdLongWeekDay, stdPM, stdpm, stdTZ:
+		// Text, whose width only selects a form.
+		if width < 4 {
+			width = 0
+		}
+	}
+	return std | width<<stdArgShift | flags
+}
+
+// NoEquivalentError is returned when a layout element cannot be translated
+// into another layout language.
+type NoEquivalentError struct {
+	Layout string // the layout being translated
+	Elem   string // the element without an equivalent
+	Target string // the layout language translated into
+}
+
+func (e *NoEquivalentError) Error() string {
+	return "jodatime: " + quote(e.Elem) + " in layout " + quote(e.Layout) + " has no equivalent in " + e.Target
+}
+
+func quote(s string) string {
+	return "\"" + s + "\""
+}
+
+// ToGoLayout translates a Joda layout into a layout of Go's time package,
+// such as "yyyy-MM-dd'T'HH:mm:ssZZ" into "2006-01-02T15:04:05-07:00".
+// Fractions of more than three S letters, which omit trailing zeros,
+// become Go's ".999" form. Elements Go has no equivalent for, such as D,
+// week years, or y, which prints the year 999 as "999" rather than "0999",
+// and literal text Go would take for an element, are reported by a
+// *NoEquivalentError.
+func ToGoLayout(joda string) (string, error) {
+	var b []byte
+	var stds []int
+	var elems []string // the Joda elements of stds
+	for layout := joda; layout != ""; {
+		prefix, std, suffix := nextStdChunk(layout)
+		if prefix != "" {
+			if goLiteral.Format(prefix) != prefix {
+				return "", &NoEquivalentError{joda, prefix, "Go layouts"}
+			}
+			b = append(b, prefix...)
+		}
+		if std == 0 {
+			break
+		}
+		elem := Joda.stdText(layout, suffix)
+		layout = suffix
+		if s := std & stdMask; s == stdFracSecond0 || s == stdFracSecond9 {
+			// Go fractions start with their separator.
+			width := std >> stdArgShift & stdWidthMask
+			if n := len(b); n == 0 || b[n-1] != '.' && b[n-1] != ',' || width > 9 {
+				return "", &NoEquivalentError{joda, elem, "Go layouts"}
+			}
+			digit := byte('0')
+			if s == stdFracSecond9 {
+				digit = '9'
+			}
+			b = append(b, strings.Repeat(string(digit), width)...)
+			stds = append(stds, s|width<<stdArgShift)
+			elems = append(elems, elem)
+			continue
+		}
+		token := lookupToken(std, func(t layoutToken) string { return t.goLayout })
+		if token == "" {
+			return "", &NoEquivalentError{joda, elem, "Go layouts"}
+		}
+		b = append(b, token...)
+		stds = append(stds, canonicalStd(std))
+		elems = append(elems, elem)
+	}
+	// Adjacent text may run into a different element, as in "Jan" + "uary".
+	goLayout := string(b)
+	i := 0
+	for layout := goLayout; ; i++ {
+		prefix, std, suffix := nextGoChunk(layout)
+		if std == 0 {
+			break
+		}
+		if s := std & stdMask; s != stdFracSecond0 && s != stdFracSecond9 {
+			std = canonicalStd(std)
+		}
+		if i >= len(stds) {
+			// Literal text became an element of its own.
+			return "", &NoEquivalentError{joda, layout[len(prefix) : len(layout)-len(suffix)], "Go layouts"}
+		}
+		if stds[i] != std {
+			return "", &NoEquivalentError{joda, elems[i], "Go layouts"}
+		}
+		layout = suffix
+	}
+	if i != len(stds) {
+		return "", &NoEquivalentError{joda, elems[i], "Go layouts"}
+	}
+	return goLayout, nil
+}
+
+// FromGoLayout translates a layout of Go's time package into a Joda layout,
+// such as "2006-01-02T15:04:05Z07:00" into "yyyy-MM-dd'T'HH:mm:ssZZ". Joda
+// prints a zero offset as "+00:00" rather than "Z", but parses either.
+// Elements Joda has no equivalent for, such as "_2", are reported by a
+// *NoEquivalentError.
+func FromGoLayout(goLayout string) (string, error) {
+	var b []byte
+	for layout := goLayout; layout != ""; {
+		prefix, std, suffix := nextGoChunk(layout)
+		b = appendJodaLiteral(b, prefix)
+		if std == 0 {
+			break
+		}
+		elem := layout[len(prefix) : len(layout)-len(suffix)]
+		layout = suffix
+		if s := std & stdMask; s == stdFracSecond0 || s == stdFracSecond9 {
+			// Trailing zeros are kept by Joda up to three digits only.
+			width := std >> stdArgShift & stdWidthMask
+			if (s == stdFracSecond0) != (width <= 3) {
+				return "", &NoEquivalentError{goLayout, elem, "Joda layouts"}
+			}
+			b = append(b, strings.Repeat("S", width)...)
+			continue
+		}
+		token := lookupToken(std, func(t layoutToken) string { return t.joda })
+		if token == "" {
+			return "", &NoEquivalentError{goLayout, elem, "Joda layouts"}
+		}
+		b = append(b, token...)
+	}
+	return string(b), nil
+}
+
+// ToStrftimeLayout translates a Joda layout into a strftime layout, such
+// as "yyyy-MM-dd'T'HH:mm:ss.SSSZ" into "%Y-%m-%dT%H:%M:%S.%3N%z". Fractions
+// become "%f" for six digits and GNU's "%3N" otherwise; Joda omits their
+// trailing zeros beyond three digits. Elements strftime has no equivalent
+// for are reported by a *NoEquivalentError.
+func ToStrftimeLayout(joda string) (string, error) {
+	var b []byte
+	for layout := joda; layout != ""; {
+		prefix, std, suffix := nextStdChunk(layout)
+		b = append(b, strings.Replace(prefix, "%", "%%", -1)...)
+		if std == 0 {
+			break
+		}
+		elem := Joda.stdText(layout, suffix)
+		layout = suffix
+		if s := std & stdMask; s == stdFracSecond0 || s == stdFracSecond9 {
+			switch width := std >> stdArgShift & stdWidthMask; {
+			case width == 6:
+				b = append(b, "%f"...)
+			case width <= 9:
+				b = append(b, '%')
+				b = appendInt(b, width, 0)
+				b = append(b, 'N')
+			default:
+				return "", &NoEquivalentError{joda, elem, "strftime layouts"}
+			}
+			continue
+		}
+		token := lookupToken(std, func(t layoutToken) string { return t.strftime })
+		if token == "" {
+			return "", &NoEquivalentError{joda, elem, "strftime layouts"}
+		}
+		b = append(b, token...)
+	}
+	return string(b), nil
+}
+
+// FromStrftimeLayout translates a strftime layout into a Joda layout, such
+// as "%Y-%m-%dT%H:%M:%S.%f%z" into "yyyy-MM-dd'T'HH:mm:ss.SSSSSSZ".
+// Elements Joda has no equivalent for, such as "%e", are reported by a
+// *NoEquivalentError.
+func FromStrftimeLayout(layout string) (string, error) {
+	var b []byte
+	original := layout
+	layout = expandStrftime(layout)
+	for layout != "" {
+		prefix, std, suffix := nextStrftimeChunk(layout)
+		b = appendJodaLiteral(b, prefix)
+		if std == 0 {
+			break
+		}
+		elem := Strftime.stdText(layout, suffix)
+		layout = suffix
+		if s := std & stdMask; s == stdFracSecond0 {
+			b = append(b, strings.Repeat("S", std>>stdArgShift&stdWidthMask)...)
+			continue
+		}
+		token := lookupToken(std, func(t layoutToken) string { return t.joda })
+		if token == "" {
+			return "", &NoEquivalentError{original, elem, "Joda layouts"}
+		}
+		b = append(b, token...)
+	}
+	return string(b), nil
+}
+
+// lookupToken returns the element of a layout language, selected by lang,
+// that is equivalent to std.
+func lookupToken(std int, lang func(layoutToken) string) string {
+	std = canonicalStd(std)
+	for _, t := range layoutTokens {
+		if t.std == std {
+			if s := lang(t); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// appendJodaLiteral appends text to a Joda layout, quoting letters and
+// quotes.
+func appendJodaLiteral(b []byte, text string) []byte {
+	for i := 0; i < len(text); {
+		// Quote letters together with the quotes next to them, so that
+		// quoted runs never meet.
+		j, letters := i, false
+		for ; j < len(text) && (isLetter(text[j]) || text[j] == '\''); j++ {
+			letters = letters || text[j] != '\''
+		}
+		switch {
+		case j == i:
+			b = append(b, text[i])
+			i++
+			continue
+		case letters:
+			b = append(b, '\'')
+			b = append(b, strings.Replace(text[i:j], "'", "''", -1)...)
+			b = append(b, '\'')
+		default:
+			b = append(b, strings.Replace(text[i:j], "'", "''", -1)...)
+		}
+		i = j
+	}
+	return b
+}
+
+// goLiteral is a time none of whose fields formats like the element of a
+// Go layout it stands for, so that text is literal in a Go layout exactly
+// when goLiteral formats it unchanged.
+var goLiteral = time.Date(1999, time.November, 23, 1, 33, 44, 555555555, time.FixedZone("AAA", 5*3600+30*60))
+
+// nextGoChunk finds the first occurrence of a std string of a Go layout and
+// returns the text before, the std string, and the text after, like the
+// time package does. The separator of a fractional second ends the text
+// before.
+func nextGoChunk(layout string) (prefix string, std int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case 'J': // January, Jan
+			if len(layout) >= i+3 && layout[i:i+3] == "Jan" {
+				if len(layout) >= i+7 && layout[i:i+7] == "January" {
+					return layout[0:i], stdLongMonth, layout[i+7:]
+				}
+				if !startsWithLowerCase(layout[i+3:]) {
+					return layout[0:i], stdMonth, layout[i+3:]
+				}
+			}
+		case 'M': // Monday, Mon, MST
+			if len(layout) >= i+3 {
+				if layout[i:i+3] == "Mon" {
+					if len(layout) >= i+6 && layout[i:i+6] == "Monday" {
+						return layout[0:i], stdLongWeekDay, layout[i+6:]
+					}
+					if !startsWithLowerCase(layout[i+3:]) {
+						return layout[0:i], stdWeekDay, layout[i+3:]
+					}
+				}
+				if layout[i:i+3] == "MST" {
+					return layout[0:i], stdTZ, layout[i+3:]
+				}
+			}
+		case '0': // 01, 02, 03, 04, 05, 06, 002
+			if len(layout) >= i+2 && '1' <= layout[i+1] && layout[i+1] <= '6' {
+				return layout[0:i], std0x[layout[i+1]-'1'] | 2<<stdArgShift, layout[i+2:]
+			}
+			if len(layout) >= i+3 && layout[i+1] == '0' && layout[i+2] == '2' {
+				return layout[0:i], stdYearDay | 3<<stdArgShift, layout[i+3:]
+			}
+		case '1': // 15, 1
+			if len(layout) >= i+2 && layout[i+1] == '5' {
+				return layout[0:i], stdHour | 2<<stdArgShift, layout[i+2:]
+			}
+			return layout[0:i], stdNumMonth | 1<<stdArgShift, layout[i+1:]
+		case '2': // 2006, 2
+			if len(layout) >= i+4 && layout[i:i+4] == "2006" {
+				return layout[0:i], stdLongYear | 4<<stdArgShift, layout[i+4:]
+			}
+			return layout[0:i], stdDay | 1<<stdArgShift, layout[i+1:]
+		case '_': // _2, _2006, __2
+			if len(layout) >= i+2 && layout[i+1] == '2' {
+				// _2006 is really a literal _, followed by the long year.
+				if len(layout) >= i+5 && layout[i+1:i+5] == "2006" {
+					return layout[0 : i+1], stdLongYear | 4<<stdArgShift, layout[i+5:]
+				}
+				return layout[0:i], stdUnderDay | 2<<stdArgShift | stdSpacePad, layout[i+2:]
+			}
+			if len(layout) >= i+3 && layout[i+1] == '_' && layout[i+2] == '2' {
+				return layout[0:i], stdYearDay | 3<<stdArgShift | stdSpacePad, layout[i+3:]
+			}
+		case '3':
+			return layout[0:i], stdHour12 | 1<<stdArgShift, layout[i+1:]
+		case '4':
+			return layout[0:i], stdMinute | 1<<stdArgShift, layout[i+1:]
+		case '5':
+			return layout[0:i], stdSecond | 1<<stdArgShift, layout[i+1:]
+		case 'P': // PM
+			if len(layout) >= i+2 && layout[i+1] == 'M' {
+				return layout[0:i], stdPM, layout[i+2:]
+			}
+		case 'p': // pm
+			if len(layout) >= i+2 && layout[i+1] == 'm' {
+				return layout[0:i], stdpm, layout[i+2:]
+			}
+		case '-', 'Z': // -070000, -07:00:00, -0700, -07:00, -07 and the Z forms
+			for _, z := range [...]struct {
+				text     string
+				num, iso int
+			}{
+				{"070000", stdNumSecondsTz, stdISO8601SecondsTZ},
+				{"07:00:00", stdNumColonSecondsTZ, stdISO8601ColonSecondsTZ},
+				{"0700", stdNumTZ, stdISO8601TZ},
+				{"07:00", stdNumColonTZ, stdISO8601ColonTZ},
+				{"07", stdNumShortTZ, stdISO8601ShortTZ},
+			} {
+				if strings.HasPrefix(layout[i+1:], z.text) {
+					if c == '-' {
+						return layout[0:i], z.num, layout[i+1+len(z.text):]
+					}
+					return layout[0:i], z.iso, layout[i+1+len(z.text):]
+				}
+			}
+		case '.', ',': // .000, .999, ,000, ,999 - repeated digits for fractional seconds
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				ch := layout[i+1]
+				j := i + 1
+				for j < len(layout) && layout[j] == ch {
+					j++
+				}
+				// The digits must end here, all the same.
+				if !isDigit(layout, j) {
+					std := stdFracSecond0
+					if ch == '9' {
+						std = stdFracSecond9
+					}
+					return layout[0 : i+1], std | (j-i-1)<<stdArgShift, layout[j:]
+				}
+			}
+		}
+	}
+	return layout, 0, ""
+}
@@ -0,0 +1,121 @@
+package jodatime_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestGoLayout(t *testing.T) {
+	tests := []struct {
+		joda, goLayout string
+	}{
+		{"yyyy-MM-dd'T'HH:mm:ssZZ", "2006-01-02T15:04:05-07:00"},
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSSSSSSSZZ", "2006-01-02T15:04:05.999999999-07:00"},
+		{"EEE, dd MMM yyyy HH:mm:ss ZZZ", "Mon, 02 Jan 2006 15:04:05 MST"},
+		{"EEEE MMMM d h:mm:ss a", "Monday January 2 3:04:05 PM"},
+		{"yyyyMMdd HHmmss,SSS Z", "20060102 150405,000 -0700"},
+		{"yy-M-d DDD", "06-1-2 002"},
+	}
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	for _, test := range tests {
+		goLayout, err := ToGoLayout(test.joda)
+		if err != nil {
+			t.Errorf("%s error: %v", test.joda, err)
+		} else if goLayout != test.goLayout {
+			t.Errorf("%s expected %q got %q", test.joda, test.goLayout, goLayout)
+		} else if want := Format(tm, test.joda); tm.Format(goLayout) != want {
+			t.Errorf("%s formats %q in Go, %q in Joda", test.joda, tm.Format(goLayout), want)
+		}
+		joda, err := FromGoLayout(test.goLayout)
+		if err != nil {
+			t.Errorf("%s error: %v", test.goLayout, err)
+		} else if joda != test.joda {
+			t.Errorf("%s expected %q got %q", test.goLayout, test.joda, joda)
+		}
+	}
+
+	if joda, err := FromGoLayout(time.RFC3339); err != nil || joda != "yyyy-MM-dd'T'HH:mm:ssZZ" {
+		t.Errorf("RFC3339 expected %q got %q, %v", "yyyy-MM-dd'T'HH:mm:ssZZ", joda, err)
+	}
+	if joda, err := FromGoLayout("at 3 o'clock"); err != nil || joda != "'at' h 'o''clock'" {
+		t.Errorf("expected %q got %q, %v", "'at' h 'o''clock'", joda, err)
+	}
+
+	// y and yyy print years below 1000 with fewer digits than Go's 2006.
+	old := time.Date(999, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if goLayout, err := ToGoLayout("yyyy"); err != nil || old.Format(goLayout) != Format(old, "yyyy") {
+		t.Errorf("yyyy formats %q in Go, %q in Joda, %v", old.Format(goLayout), Format(old, "yyyy"), err)
+	}
+	for _, joda := range []string{"y-MM-dd", "yyy-MM-dd"} {
+		var e *NoEquivalentError
+		if _, err := ToGoLayout(joda); !errors.As(err, &e) || e.Elem != joda[:strings.IndexByte(joda, '-')] {
+			t.Errorf("%s expected NoEquivalentError got %v", joda, err)
+		}
+	}
+	if result := Format(old, "y yyy"); result != "999 999" {
+		t.Errorf("expected %q got %q", "999 999", result)
+	}
+	var e *NoEquivalentError
+	if _, err := ToGoLayout("yyyy MMM'uary'"); !errors.As(err, &e) || e.Elem != "MMM" {
+		t.Errorf("expected NoEquivalentError for %q got %v", "MMM", err)
+	}
+
+	for _, joda := range []string{"D", "xxxx-ww", "H:mm", "yyyy-MM-dd 'Jan'", "SSS", "MMM'uary'", "G"} {
+		var e *NoEquivalentError
+		if _, err := ToGoLayout(joda); !errors.As(err, &e) {
+			t.Errorf("%s expected NoEquivalentError got %v", joda, err)
+		}
+	}
+	for _, goLayout := range []string{time.ANSIC, "pm", "-07", "05.000000", "05.99"} {
+		var e *NoEquivalentError
+		if _, err := FromGoLayout(goLayout); !errors.As(err, &e) {
+			t.Errorf("%s expected NoEquivalentError got %v", goLayout, err)
+		}
+	}
+}
+
+func TestStrftimeLayout(t *testing.T) {
+	tests := []struct {
+		joda, strftime string
+	}{
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSSSSZ", "%Y-%m-%dT%H:%M:%S.%f%z"},
+		{"EEE MMM d H:m:s yy", "%a %b %-d %-H:%-M:%-S %y"},
+		{"xxxx-'W'ww-e DDD", "%G-W%V-%u %j"},
+		{"hh:mm a ZZ ZZZ", "%I:%M %p %:z %Z"},
+		{"X.SSS 100%", "%s.%3N 100%%"},
+	}
+	for _, test := range tests {
+		strftime, err := ToStrftimeLayout(test.joda)
+		if err != nil {
+			t.Errorf("%s error: %v", test.joda, err)
+		} else if strftime != test.strftime {
+			t.Errorf("%s expected %q got %q", test.joda, test.strftime, strftime)
+		}
+		joda, err := FromStrftimeLayout(test.strftime)
+		if err != nil {
+			t.Errorf("%s error: %v", test.strftime, err)
+		} else if joda != test.joda {
+			t.Errorf("%s expected %q got %q", test.strftime, test.joda, joda)
+		}
+	}
+
+	if joda, err := FromStrftimeLayout("%F %T"); err != nil || joda != "yyyy-MM-dd HH:mm:ss" {
+		t.Errorf("expected %q got %q, %v", "yyyy-MM-dd HH:mm:ss", joda, err)
+	}
+	for _, layout := range []string{"%e", "%k", "%P", "%U", "%w"} {
+		var e *NoEquivalentError
+		if _, err := FromStrftimeLayout(layout); !errors.As(err, &e) {
+			t.Errorf("%s expected NoEquivalentError got %v", layout, err)
+		}
+	}
+	for _, joda := range []string{"G", "K", "y"} {
+		var e *NoEquivalentError
+		if _, err := ToStrftimeLayout(joda); !errors.As(err, &e) {
+			t.Errorf("%s expected NoEquivalentError got %v", joda, err)
+		}
+	}
+}
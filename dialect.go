@@ -0,0 +1,120 @@
+package jodatime
+
+import "strings"
+
+// A Dialect is a pattern language of layouts. All dialects are formatted
+// and parsed by the same engine; they differ in what the letters mean.
+type Dialect int
+
+const (
+	// Joda is the pattern language of Joda-Time's DateTimeFormat, the default.
+	// http://joda-time.sourceforge.net/apidocs/org/joda/time/format/DateTimeFormat.html
+	Joda Dialect = iota
+	// JavaTime is the pattern language of java.time's DateTimeFormatter.
+	// Week fields are those of ISO 8601, and optional sections in brackets
+	// are not supported. Letters are reserved: those without a meaning
+	// print as they are and fail to parse.
+	// https://docs.oracle.com/javase/8/docs/api/java/time/format/DateTimeFormatter.html
+	JavaTime
+	// SimpleDateFormat is the pattern language of java.text.SimpleDateFormat
+	// in lenient mode: numbers not followed by another number take any
+	// number of digits, values out of range roll over into the next field,
+	// and a two-digit year is within 80 years before and 20 years after
+	// the current year, as WithTwoDigitYearStart changes. Weeks are those
+	// of the US locale, starting on Sunday with one day enough for the
+	// first week.
+	// https://docs.oracle.com/javase/8/docs/api/java/text/SimpleDateFormat.html
+	SimpleDateFormat
+	// Strftime is the pattern language of strftime and strptime in C,
+	// Python and Ruby, in the C locale, such as "%Y-%m-%dT%H:%M:%S.%f%z".
+	// It includes the GNU flags "-", "_" and "0", field widths such as
+	// "%3N", and "%:z". Other text is literal.
+	Strftime
+	// Moment is the pattern language of Moment.js and Day.js in the English
+	// locale, such as "YYYY-MM-DD" or "dddd, MMMM Do YYYY, h:mm:ss a". Text
+	// in brackets is literal, and so are letters without a meaning. Locale
+	// weeks (w and gggg) are not supported: they format as they are and
+	// fail to parse. Parsing is always strict: numbers have the digits of
+	// their tokens, and an ordinal, as in "Do", needs its English suffix.
+	// https://momentjs.com/docs/#/displaying/format/
+	Moment
+)
+
+// next finds the first occurrence of a std string in layout, like
+// nextStdChunk does for the Joda dialect.
+func (d Dialect) next(layout string) (prefix string, std int, suffix string) {
+	switch d {
+	case JavaTime:
+		return nextChunk(layout, javaTimeStd)
+	case SimpleDateFormat:
+		return nextChunk(layout, simpleDateFormatStd)
+	case Strftime:
+		return nextStrftimeChunk(layout)
+	case Moment:
+		return nextMomentChunk(layout)
+	}
+	return nextStdChunk(layout)
+}
+
+// expand returns layout with the shorthands of d replaced by what they
+// stand for, before it is taken apart by next.
+func (d Dialect) expand(layout string) string {
+	switch d {
+	case Strftime:
+		return expandStrftime(layout)
+	case Moment:
+		return expandMoment(layout)
+	}
+	return layout
+}
+
+// stdText returns the pattern letters or directive of the std string that
+// next found in layout right before suffix. Unlike the prefix, it is never
+// rewritten by next.
+func (d Dialect) stdText(layout, suffix string) string {
+	s := layout[:len(layout)-len(suffix)]
+	if d == Strftime {
+		return s[strings.LastIndexByte(s, '%'):]
+	}
+	end := len(s)
+	if d == Moment && end > 1 && s[end-1] == 'o' {
+		// An ordinal, like "Do".
+		end--
+	}
+	i := end - 1
+	for i > 0 && s[i-1] == s[end-1] {
+		i--
+	}
+	return s[i:]
+}
+
+// lenient reports whether d parses numbers and their ranges leniently.
+func (d Dialect) lenient() bool {
+	return d == SimpleDateFormat
+}
+
+// nextChunk finds the first occurrence of a std string in layout for a
+// dialect whose runs of j letters c stand for stdOf(c, j), and returns the
+// text before, the std string, and the text after. All ASCII letters are
+// reserved, so those without a meaning are stdUnsupported.
+func nextChunk(layout string, stdOf func(c byte, j int) int) (prefix string, std int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if c == '\'' {
+			layout, i = unquote(layout, i)
+			continue
+		}
+		j := 1
+		for i+j < len(layout) && layout[i+j] == c {
+			j++
+		}
+		if std := stdOf(c, j); std != 0 {
+			return layout[0:i], std, layout[i+j:]
+		}
+		if isLetter(c) {
+			return layout[0:i], stdUnsupported, layout[i+j:]
+		}
+		i += j - 1
+	}
+	return layout, 0, ""
+}
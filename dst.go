@@ -0,0 +1,112 @@
+package jodatime
+
+import "time"
+
+// DSTPolicy selects how a wall time that is skipped (gap) or repeated
+// (overlap) by a daylight saving transition is mapped to an instant.
+type DSTPolicy int
+
+const (
+	// DSTCompatible resolves a wall time as time.Date does, which is what
+	// parsing did before policies existed: a gap as DSTLater does, an
+	// overlap as DSTEarlier does. It is the default.
+	DSTCompatible DSTPolicy = iota
+	// DSTEarlier uses the offset in effect before the transition.
+	// In a gap the wall time is moved forward by the length of the gap,
+	// in an overlap the earlier of the two instants is chosen.
+	DSTEarlier
+	// DSTLater uses the offset in effect after the transition.
+	// In a gap the wall time is moved backward by the length of the gap,
+	// in an overlap the later of the two instants is chosen.
+	DSTLater
+	// DSTShiftForward resolves a gap to the first instant after it, that is
+	// the transition itself, and an overlap to the later instant.
+	DSTShiftForward
+	// DSTReject fails the parse with a *ParseError that holds a *DSTError.
+	DSTReject
+)
+
+// A DSTError tells that a parsed wall time does not exist or exists twice
+// in its location, when the DSTReject policy rejects it. Parse returns it
+// within a *ParseError, where errors.As finds it.
+type DSTError struct {
+	Layout   string
+	Value    string
+	Location *time.Location
+	Overlap  bool // the wall time is repeated rather than skipped
+}
+
+// Error returns the string representation of a DSTError.
+func (e *DSTError) Error() string {
+	return "parsing time \"" + e.Value + "\" as \"" + e.Layout + "\": " + e.detail()
+}
+
+// detail returns what is wrong with the wall time, without the value.
+func (e *DSTError) detail() string {
+	what := "skipped"
+	if e.Overlap {
+		what = "repeated"
+	}
+	return "wall time " + what + " by daylight saving transition in " + e.Location.String()
+}
+
+// offsetAt returns the offset of loc in effect at the given Unix time.
+func offsetAt(loc *time.Location, unix int64) int {
+	_, offset := time.Unix(unix, 0).In(loc).Zone()
+	return offset
+}
+
+// date is like time.Date but resolves gaps and overlaps according to p.
+// The returned *DSTError has only Location and Overlap set.
+func (p DSTPolicy) date(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) (time.Time, error) {
+	t := time.Date(year, month, day, hour, min, sec, nsec, loc)
+	if p == DSTCompatible || loc == time.UTC {
+		return t, nil
+	}
+	if start, end := t.ZoneBounds(); (start.IsZero() || t.Sub(start) > secondsPerDay*time.Second) &&
+		(end.IsZero() || end.Sub(t) > secondsPerDay*time.Second) {
+		// No transition within a day, as in zones without any.
+		return t, nil
+	}
+
+	// Seconds of the wall clock as if it were UTC, and the offsets a day
+	// before and after it. A transition in between changes the offset.
+	wall := time.Date(year, month, day, hour, min, sec, 0, time.UTC).Unix()
+	_, offset := t.Zone()
+	before := offsetAt(loc, wall-int64(offset)-secondsPerDay)
+	after := offsetAt(loc, wall-int64(offset)+secondsPerDay)
+	if before == after {
+		return t, nil
+	}
+	validBefore := offsetAt(loc, wall-int64(before)) == before
+	validAfter := offsetAt(loc, wall-int64(after)) == after
+	if validBefore != validAfter {
+		return t, nil
+	}
+
+	nsec = t.Nanosecond()
+	overlap := validBefore
+	switch p {
+	case DSTEarlier:
+		return time.Unix(wall-int64(before), int64(nsec)).In(loc), nil
+	case DSTLater:
+		return time.Unix(wall-int64(after), int64(nsec)).In(loc), nil
+	case DSTShiftForward:
+		if overlap {
+			return time.Unix(wall-int64(after), int64(nsec)).In(loc), nil
+		}
+		// The transition lies in (wall-after, wall-before]; find the first
+		// second that already uses the new offset.
+		lo, hi := wall-int64(after), wall-int64(before)
+		for lo+1 < hi {
+			mid := lo + (hi-lo)/2
+			if offsetAt(loc, mid) == after {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		return time.Unix(hi, 0).In(loc), nil
+	}
+	return time.Time{}, &DSTError{Location: loc, Overlap: overlap}
+}
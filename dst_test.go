@@ -0,0 +1,54 @@
+package jodatime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestParseDSTDefault(t *testing.T) {
+	// Without a policy a gap parses as time.Date puts it, as it always
+	// did, not as DSTEarlier does.
+	tm, err := ParseInLocation("YYYY-MM-dd HH:mm", "2024-03-10 02:30", local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, time.March, 10, 2, 30, 0, 0, local); !tm.Equal(want) {
+		t.Errorf("expected %v got %v", want, tm)
+	}
+	if result := Format(tm, RFC3339); result != "2024-03-10T01:30:00-08:00" {
+		t.Errorf("expected %q got %q", "2024-03-10T01:30:00-08:00", result)
+	}
+}
+
+func TestParseDSTZone(t *testing.T) {
+	// A zone ID in the value is resolved by the policy too.
+	const layout = "uuuu-MM-dd HH:mm VV"
+	for _, test := range dstTests {
+		tm, err := Parse(layout, test.value+" America/Los_Angeles", WithDialect(JavaTime), WithDSTPolicy(test.policy))
+		if test.result == "" {
+			var e *DSTError
+			if !errors.As(err, &e) {
+				t.Errorf("%s expected *DSTError got %v", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+		} else if result := Format(tm, RFC3339); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	// The zone of "Z" in Joda layouts, UTC, has no transitions.
+	for _, policy := range []DSTPolicy{DSTCompatible, DSTEarlier, DSTLater, DSTShiftForward, DSTReject} {
+		tm, err := Parse("yyyy-MM-dd HH:mmZZ", "2024-03-10 02:30Z", WithDSTPolicy(policy))
+		if err != nil {
+			t.Errorf("policy %d error: %v", policy, err)
+		} else if want := time.Date(2024, time.March, 10, 2, 30, 0, 0, time.UTC); !tm.Equal(want) {
+			t.Errorf("policy %d expected %v got %v", policy, want, tm)
+		}
+	}
+}
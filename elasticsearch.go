@@ -0,0 +1,183 @@
+package jodatime
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// An ElasticsearchFormat parses and formats dates like the format of an
+// Elasticsearch or OpenSearch date field, such as
+// "yyyy-MM-dd HH:mm:ss||yyyy-MM-dd||epoch_millis". Alternatives separated
+// by "||" are tried in order when parsing, and the first one is used for
+// formatting. An alternative is either the name of a built-in format, like
+// "strict_date_optional_time", or a Joda layout.
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-date-format.html
+type ElasticsearchFormat struct {
+	format  string
+	printer *Formatter
+	parsers []*Formatter
+}
+
+// NewElasticsearchFormat returns the ElasticsearchFormat for format.
+// The options apply to all of its layouts.
+func NewElasticsearchFormat(format string, opts ...Option) (*ElasticsearchFormat, error) {
+	f := &ElasticsearchFormat{format: format}
+	for _, alt := range strings.Split(format, "||") {
+		if alt == "" {
+			return nil, errors.New("jodatime: empty format in " + format)
+		}
+		layouts, named := elasticsearchLayouts(alt)
+		if layouts == nil {
+			if named {
+				return nil, errors.New("jodatime: unknown named format " + alt)
+			}
+			layouts = []string{alt}
+		}
+		altOpts := opts
+		if named {
+			// The built-in formats accept one up to nine fractional digits
+			// and format a zero offset as "Z".
+			altOpts = append([]Option{WithFractionParsing(FractionLenient), withZeroOffsetZ}, opts...)
+		}
+		for _, layout := range layouts {
+			f.parsers = append(f.parsers, NewFormatter(layout, altOpts...))
+		}
+		if f.printer == nil {
+			f.printer = f.parsers[0]
+		}
+	}
+	return f, nil
+}
+
+// ParseElasticsearch parses value with the Elasticsearch format.
+func ParseElasticsearch(format, value string) (time.Time, error) {
+	f, err := NewElasticsearchFormat(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.Parse(value)
+}
+
+// String returns the format f was created from.
+func (f *ElasticsearchFormat) String() string {
+	return f.format
+}
+
+// Parse parses value with the first alternative of f that accepts it.
+// Values without a time zone are UTC. If none does, it returns the error
+// of the alternative that got furthest into value, the first of those
+// that got as far.
+func (f *ElasticsearchFormat) Parse(value string) (time.Time, error) {
+	var best error
+	bestOffset := -1
+	for _, p := range f.parsers {
+		t, err := p.Parse(value)
+		if err == nil {
+			return t, nil
+		}
+		offset := 0
+		var e *ParseError
+		if errors.As(err, &e) {
+			offset = e.Offset
+		}
+		if offset > bestOffset {
+			best, bestOffset = err, offset
+		}
+	}
+	return time.Time{}, best
+}
+
+// Format formats t with the first alternative of f.
+func (f *ElasticsearchFormat) Format(t time.Time) string {
+	return f.printer.Format(t)
+}
+
+// elasticsearchLayouts returns the layouts of a built-in format, the first
+// one being used for formatting, and whether alt is meant as a name.
+func elasticsearchLayouts(alt string) (layouts []string, named bool) {
+	name := strings.TrimPrefix(alt, "strict_")
+	layouts, named = elasticsearchNamed[name]
+	if !named {
+		// Anything else, like dd_MM_yyyy, is a layout.
+		return nil, name != alt
+	}
+	if name != alt || strings.HasPrefix(name, "basic_") || strings.HasPrefix(name, "epoch_") {
+		return layouts, true
+	}
+	// Without strict_ a field may have fewer digits than letters.
+	lenient := make([]string, 0, 2*len(layouts))
+	lenient = append(lenient, layouts...)
+	for _, layout := range layouts {
+		lenient = append(lenient, elasticsearchLenient.Replace(layout))
+	}
+	return lenient, true
+}
+
+var elasticsearchLenient = strings.NewReplacer(
+	"MM", "M", "dd", "d", "HH", "H", "mm", "m", "ss", "s", "ww", "w", "DDD", "D",
+)
+
+// optionalTime returns the layouts of a date, optionally followed by a time
+// with optional minutes, seconds, fraction and zone, longest first.
+func optionalTime(date string, partial bool) []string {
+	var layouts []string
+	for _, t := range []string{"'T'HH:mm:ss.SSS", "'T'HH:mm:ss", "'T'HH:mm", "'T'HH"} {
+		layouts = append(layouts, date+t+"ZZ", date+t)
+	}
+	layouts = append(layouts, date)
+	if partial {
+		layouts = append(layouts, "yyyy-MM", "yyyy")
+	}
+	return layouts
+}
+
+// elasticsearchNamed are the built-in formats without the strict_ prefix.
+var elasticsearchNamed = map[string][]string{
+	"epoch_millis":                      {EpochMillis},
+	"epoch_second":                      {EpochSecond},
+	"date_optional_time":                optionalTime("yyyy-MM-dd", true),
+	"date_optional_time_nanos":          optionalTime("yyyy-MM-dd", true),
+	"basic_date":                        {"yyyyMMdd"},
+	"basic_date_time":                   {"yyyyMMdd'T'HHmmss.SSSZ"},
+	"basic_date_time_no_millis":         {"yyyyMMdd'T'HHmmssZ"},
+	"basic_ordinal_date":                {"yyyyDDD"},
+	"basic_ordinal_date_time":           {"yyyyDDD'T'HHmmss.SSSZ"},
+	"basic_ordinal_date_time_no_millis": {"yyyyDDD'T'HHmmssZ"},
+	"basic_time":                        {"HHmmss.SSSZ"},
+	"basic_time_no_millis":              {"HHmmssZ"},
+	"basic_t_time":                      {"'T'HHmmss.SSSZ"},
+	"basic_t_time_no_millis":            {"'T'HHmmssZ"},
+	"basic_week_date":                   {"xxxx'W'wwe"},
+	"basic_week_date_time":              {"xxxx'W'wwe'T'HHmmss.SSSZ"},
+	"basic_week_date_time_no_millis":    {"xxxx'W'wwe'T'HHmmssZ"},
+	"date":                              {"yyyy-MM-dd"},
+	"date_hour":                         {"yyyy-MM-dd'T'HH"},
+	"date_hour_minute":                  {"yyyy-MM-dd'T'HH:mm"},
+	"date_hour_minute_second":           {"yyyy-MM-dd'T'HH:mm:ss"},
+	"date_hour_minute_second_fraction":  {"yyyy-MM-dd'T'HH:mm:ss.SSS"},
+	"date_hour_minute_second_millis":    {"yyyy-MM-dd'T'HH:mm:ss.SSS"},
+	"date_time":                         {"yyyy-MM-dd'T'HH:mm:ss.SSSZZ"},
+	"date_time_no_millis":               {"yyyy-MM-dd'T'HH:mm:ssZZ"},
+	"hour":                              {"HH"},
+	"hour_minute":                       {"HH:mm"},
+	"hour_minute_second":                {"HH:mm:ss"},
+	"hour_minute_second_fraction":       {"HH:mm:ss.SSS"},
+	"hour_minute_second_millis":         {"HH:mm:ss.SSS"},
+	"ordinal_date":                      {"yyyy-DDD"},
+	"ordinal_date_time":                 {"yyyy-DDD'T'HH:mm:ss.SSSZZ"},
+	"ordinal_date_time_no_millis":       {"yyyy-DDD'T'HH:mm:ssZZ"},
+	"time":                              {"HH:mm:ss.SSSZZ"},
+	"time_no_millis":                    {"HH:mm:ssZZ"},
+	"t_time":                            {"'T'HH:mm:ss.SSSZZ"},
+	"t_time_no_millis":                  {"'T'HH:mm:ssZZ"},
+	"week_date":                         {"xxxx-'W'ww-e"},
+	"week_date_time":                    {"xxxx-'W'ww-e'T'HH:mm:ss.SSSZZ"},
+	"week_date_time_no_millis":          {"xxxx-'W'ww-e'T'HH:mm:ssZZ"},
+	"weekyear":                          {"xxxx"},
+	"weekyear_week":                     {"xxxx-'W'ww"},
+	"weekyear_week_day":                 {"xxxx-'W'ww-e"},
+	"year":                              {"yyyy"},
+	"year_month":                        {"yyyy-MM"},
+	"year_month_day":                    {"yyyy-MM-dd"},
+}
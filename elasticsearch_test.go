@@ -0,0 +1,89 @@
+package jodatime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var elasticsearchTests = []struct {
+	format string
+	value  string
+	result string // RFC3339Nano in UTC, empty for an error
+}{
+	{"yyyy-MM-dd HH:mm:ss||yyyy-MM-dd||epoch_millis", "2018-09-19 19:50:26", "2018-09-19T19:50:26Z"},
+	{"yyyy-MM-dd HH:mm:ss||yyyy-MM-dd||epoch_millis", "2018-09-19", "2018-09-19T00:00:00Z"},
+	{"yyyy-MM-dd HH:mm:ss||yyyy-MM-dd||epoch_millis", "1537386626208", "2018-09-19T19:50:26.208Z"},
+	{"yyyy-MM-dd HH:mm:ss||yyyy-MM-dd||epoch_millis", "19/09/2018", ""},
+	{"strict_date_optional_time", "2018-09-19T19:50:26.208Z", "2018-09-19T19:50:26.208Z"},
+	{"strict_date_optional_time", "2018-09-19T19:50:26.208123456+02:00", "2018-09-19T17:50:26.208123456Z"},
+	{"strict_date_optional_time", "2018-09-19T19:50", "2018-09-19T19:50:00Z"},
+	{"strict_date_optional_time", "2018-09", "2018-09-01T00:00:00Z"},
+	{"strict_date_optional_time", "2018-9-19", ""},
+	{"date_optional_time", "2018-9-19T7:05:00Z", "2018-09-19T07:05:00Z"},
+	{"epoch_millis", "-1000", "1969-12-31T23:59:59Z"},
+	// As in Elasticsearch, a number may also be a year.
+	{"strict_date_optional_time||epoch_millis", "1000", "1000-01-01T00:00:00Z"},
+	{"basic_date_time", "20180919T195026.208+0000", "2018-09-19T19:50:26.208Z"},
+	{"basic_date", "20180919", "2018-09-19T00:00:00Z"},
+	{"date_hour_minute", "2018-09-19T19:50", "2018-09-19T19:50:00Z"},
+	{"ordinal_date", "2018-262", "2018-09-19T00:00:00Z"},
+	{"week_date", "2018-W38-3", "2018-09-19T00:00:00Z"},
+	{"basic_week_date", "2018W383", "2018-09-19T00:00:00Z"},
+	{"week_date", "2009-W53-1", "2009-12-28T00:00:00Z"},
+	{"week_date", "2010-W53-1", ""},
+	{"epoch_second", "1537386626.5", "2018-09-19T19:50:26.5Z"},
+	// A name that is not built in is a layout.
+	{"dd_MM_yyyy", "19_09_2018", "2018-09-19T00:00:00Z"},
+	{"dd_MM_yyyy||basic_date", "20180919", "2018-09-19T00:00:00Z"},
+}
+
+func TestElasticsearch(t *testing.T) {
+	for _, test := range elasticsearchTests {
+		tm, err := ParseElasticsearch(test.format, test.value)
+		if test.result == "" {
+			if err == nil {
+				t.Errorf("%s %q expected error", test.format, test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.format, test.value, err)
+		} else if result := tm.UTC().Format(time.RFC3339Nano); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.format, test.value, test.result, result)
+		}
+	}
+
+	f, err := NewElasticsearchFormat("strict_date_optional_time||epoch_millis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := time.Date(2018, time.September, 19, 19, 50, 26, 208000000, time.UTC)
+	if result := f.Format(tm); result != "2018-09-19T19:50:26.208Z" {
+		t.Errorf("Format expected %q got %q", "2018-09-19T19:50:26.208Z", result)
+	}
+	if result := f.Format(tm.In(time.FixedZone("", 7200))); result != "2018-09-19T21:50:26.208+02:00" {
+		t.Errorf("Format expected %q got %q", "2018-09-19T21:50:26.208+02:00", result)
+	}
+	f, _ = NewElasticsearchFormat("yyyy-MM-dd'T'HH:mmZZ")
+	if result := f.Format(tm); result != "2018-09-19T19:50+00:00" {
+		t.Errorf("Format expected %q got %q", "2018-09-19T19:50+00:00", result)
+	}
+
+	// The error is that of the alternative that got furthest.
+	for _, format := range []string{"yyyy-MM-dd||yyyy/MM/dd", "yyyy/MM/dd||yyyy-MM-dd"} {
+		_, err = ParseElasticsearch(format, "2018-09-19T19:50")
+		var e *ParseError
+		if !errors.As(err, &e) || e.Layout != "yyyy-MM-dd" || e.Offset != 10 || e.Reason != ReasonExtraText {
+			t.Errorf("%s expected extra text after yyyy-MM-dd got %v", format, err)
+		}
+	}
+
+	for _, format := range []string{"strict_no_such_format", "yyyy||", ""} {
+		if _, err := NewElasticsearchFormat(format); err == nil {
+			t.Errorf("%q expected error", format)
+		}
+	}
+}
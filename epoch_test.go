@@ -0,0 +1,55 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var epochTests = []struct {
+	name   string
+	format string
+	value  string
+	unix   int64 // nanoseconds
+	result string
+}{
+	{"seconds", EpochSecond, "1233810057", 1233810057000000000, "1233810057"},
+	{"millis", EpochMillis, "1233810057012", 1233810057012000000, "1233810057012"},
+	{"micros", EpochMicros, "1233810057012345", 1233810057012345000, "1233810057012345"},
+	{"nanos", EpochNanos, "1233810057012345600", 1233810057012345600, "1233810057012345600"},
+	{"negative seconds", EpochSecond, "-86400", -86400000000000, "-86400"},
+	{"negative millis", EpochMillis, "-1500", -1500000000, "-1500"},
+	{"fractional seconds", EpochSecond, "1715000000.123", 1715000000123000000, "1715000000"},
+	{"negative fractional seconds", EpochSecond, "-1.5", -1500000000, "-2"},
+	{"fractional millis", EpochMillis, "1715000000123.456", 1715000000123456000, "1715000000123"},
+	{"with fraction field", "X.SSS", "1715000000.123", 1715000000123000000, "1715000000.123"},
+	{"in layout", "'ts='X' level=info'", "ts=1715000000 level=info", 1715000000000000000, "ts=1715000000 level=info"},
+}
+
+func TestEpoch(t *testing.T) {
+	for _, test := range epochTests {
+		tm, err := Parse(test.format, test.value)
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+			continue
+		}
+		if tm.UnixNano() != test.unix {
+			t.Errorf("%s expected %d got %d", test.name, test.unix, tm.UnixNano())
+		}
+		if result := Format(tm, test.format); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	tm, err := Parse("X ZZ", "1233810057 -08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := Format(tm, RFC3339); result != "2009-02-04T21:00:57-08:00" {
+		t.Errorf("epoch with zone expected %q got %q", "2009-02-04T21:00:57-08:00", result)
+	}
+	if tm.Location() != time.Local {
+		t.Errorf("epoch with local offset expected Local got %v", tm.Location())
+	}
+}
@@ -0,0 +1,40 @@
+package jodatime
+
+import (
+	"regexp"
+	"time"
+)
+
+// A Match is a time found in text by FindAll.
+type Match struct {
+	Start, End int       // byte offsets of the time in the text
+	Time       time.Time // the parsed time
+}
+
+// FindAll returns the times in text that parse under layout, from left to
+// right and not overlapping, as Parse would return them. Each starts where
+// the text first parses, and ends where parsing the layout stops. It
+// returns nil if there is none.
+func FindAll(layout, text string, opts ...Option) []Match {
+	o := newOptions(opts)
+	// The regular expression only skips ahead to where parsing may succeed.
+	re, err := regexp.Compile(o.layoutRegexp(layout))
+	var matches []Match
+	for i := 0; i < len(text); {
+		if err == nil {
+			loc := re.FindStringIndex(text[i:])
+			if loc == nil {
+				break
+			}
+			i += loc[0]
+		}
+		t, n, perr := parsePrefix(layout, text[i:], time.UTC, time.Local, &o)
+		if perr == nil && n > 0 {
+			matches = append(matches, Match{Start: i, End: i + n, Time: t})
+			i += n
+			continue
+		}
+		i++
+	}
+	return matches
+}
@@ -0,0 +1,45 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "2009-02-04 21:00:57 started\nretry at 2009-02-04 21:01:03.5, bad 2009-13-01 00:00:00, done 2009-02-04 21:02:00"
+	matches := FindAll("yyyy-MM-dd HH:mm:ss", text)
+	want := []struct {
+		text   string
+		result string
+	}{
+		{"2009-02-04 21:00:57", "2009-02-04 21:00:57.000"},
+		{"2009-02-04 21:01:03.5", "2009-02-04 21:01:03.500"},
+		{"2009-02-04 21:02:00", "2009-02-04 21:02:00.000"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("expected %d matches got %v", len(want), matches)
+	}
+	for i, m := range matches {
+		if got := text[m.Start:m.End]; got != want[i].text {
+			t.Errorf("match %d expected %q got %q", i, want[i].text, got)
+		}
+		if result := Format(m.Time, "yyyy-MM-dd HH:mm:ss.SSS"); result != want[i].result {
+			t.Errorf("match %d expected %q got %q", i, want[i].result, result)
+		}
+	}
+
+	log := `127.0.0.1 - - [04/Feb/2009:21:00:57 -0800] "GET / HTTP/1.1" 200`
+	matches = FindAll("%d/%b/%Y:%H:%M:%S %z", log, WithDialect(Strftime))
+	if len(matches) != 1 || matches[0].Start != 15 || matches[0].End != 41 {
+		t.Fatalf("expected one match at 15:41 got %v", matches)
+	}
+	if !matches[0].Time.Equal(time.Unix(1233810057, 0)) {
+		t.Errorf("expected %v got %v", time.Unix(1233810057, 0), matches[0].Time)
+	}
+
+	if matches := FindAll("yyyy-MM-dd", "no dates here, 2009-02-30 neither"); matches != nil {
+		t.Errorf("expected no matches got %v", matches)
+	}
+}
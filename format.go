@@ -16,6 +16,11 @@ const (
 	RFC3339     = "YYYY-MM-ddTHH:mm:ssZZ"
 	RFC3339Nano = "YYYY-MM-ddTHH:mm:ss.SSSSSSSSSZZ"
 	Kitchen     = "h:mma"
+	// Time since the Unix epoch.
+	EpochSecond = "X"
+	EpochMillis = "XXX"
+	EpochMicros = "XXXXXX"
+	EpochNanos  = "XXXXXXXXX"
 )
 
 const (
@@ -53,11 +58,45 @@ const (
 	stdNumColonSecondsTZ                           // "-07:00:00"
 	stdFracSecond0                                 // ".0", ".00", ... , trailing zeros included
 	stdFracSecond9                                 // ".9", ".99", ..., trailing zeros omitted
+	stdEpoch                                       // "X", "XXX", "XXXXXX", "XXXXXXXXX": seconds, ms, µs, ns since the epoch
+	stdYearDay               = iota + stdNeedDate  // "D", "DDD": day of year
+	stdWeekYear              = iota                // "xxxx": ISO 8601 week-based year
+	stdWeek                                        // "w", "ww": ISO 8601 week of the week-based year
+	stdISOWeekDay                                  // "e": ISO 8601 day of week, Monday is 1
+	stdEra                   = iota + stdNeedDate  // "AD"
+	stdQuarter                                     // "1": quarter of year
+	stdQuarterText                                 // "Q1", "1st quarter"
+	stdHour11                = iota + stdNeedClock // "K": hour of half day, 0 to 11
+	stdHour24                                      // "k": hour of day, 1 to 24
+	stdMilliOfDay                                  // "A": milliseconds since midnight
+	stdNanoOfDay                                   // "N": nanoseconds since midnight
+	stdNanoSecond            = iota                // "n": nanoseconds of the second
+	stdZoneID                                      // "America/Los_Angeles"
+	stdGMTOffset                                   // "GMT-8", "GMT-08:00"
+	stdWeekOfMonth           = iota + stdNeedDate  // "W": week of month
+	stdWeekDayInMonth                              // "F": occurrence of the day of week in the month
+	stdMillisecond           = iota + stdNeedClock // "S" of SimpleDateFormat: milliseconds as a number
+	stdNumWeekDay            = iota + stdNeedDate  // "%w": day of week, Sunday is 0
+	stdSundayWeek                                  // "%U": week of year from the first Sunday
+	stdMondayWeek                                  // "%W": week of year from the first Monday
+	stdUnsupported           = iota                // pattern letters the dialect has no meaning for, as "VVV"
 
-	stdNeedDate  = 1 << 8             // need month, day, year
-	stdNeedClock = 2 << 8             // need hour, minute, second
-	stdArgShift  = 16                 // extra argument in high bits, above low stdArgShift
-	stdMask      = 1<<stdArgShift - 1 // mask out argument
+	stdNeedDate  = 1 << 8              // need month, day, year
+	stdNeedClock = 2 << 8              // need hour, minute, second
+	stdArgShift  = 16                  // extra argument in high bits, above low stdArgShift
+	stdMask      = 1<<stdArgShift - 1  // mask out argument
+	stdWidthMask = 1<<8 - 1            // the argument starts with the number of pattern letters
+	stdFlagShift = stdArgShift + 8     // and goes on with flags
+	stdZeroZ     = 1 << stdFlagShift   // numeric zone that also parses "Z" as UTC
+	stdOptional  = 2 << stdFlagShift   // zone minutes or seconds are left out when zero
+	stdYearOfEra = 4 << stdFlagShift   // year counted from 1 in both eras
+	stdBase2000  = 8 << stdFlagShift   // two-digit year in 2000 to 2099
+	stdSignPad   = 16 << stdFlagShift  // sign of a year that exceeds its width
+	stdBaseNow   = 32 << stdFlagShift  // two-digit year around the current year
+	stdSpacePad  = 64 << stdFlagShift  // number padded with spaces
+	stdVarDigits = 128 << stdFlagShift // fraction of one up to width digits
+	stdOrdinal   = 256 << stdFlagShift // number followed by its English suffix, as in "1st"
+	stdUSWeek    = 512 << stdFlagShift // week of the US locale, starting on Sunday, with January 1 in week 1
 )
 
 // std0x records the std values for "01", "02", ..., "06".
@@ -88,9 +127,9 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			}
 			switch j {
 			case 1: // d
-				return layout[0:i], stdHour12, layout[i+j:]
+				return layout[0:i], stdHour12 | j<<stdArgShift, layout[i+j:]
 			default:
-				return layout[0:i], stdZeroHour12, layout[i+j:]
+				return layout[0:i], stdZeroHour12 | j<<stdArgShift, layout[i+j:]
 			}
 		case 'H':
 			j := 1
@@ -99,7 +138,7 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 					break
 				}
 			}
-			return layout[0:i], stdHour, layout[i+j:]
+			return layout[0:i], stdHour | j<<stdArgShift, layout[i+j:]
 		case 'm':
 			j := 1
 			for ; i+j < layoutLength; j++ {
@@ -109,9 +148,9 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			}
 			switch j {
 			case 1: // d
-				return layout[0:i], stdMinute, layout[i+j:]
+				return layout[0:i], stdMinute | j<<stdArgShift, layout[i+j:]
 			default:
-				return layout[0:i], stdZeroMinute, layout[i+j:]
+				return layout[0:i], stdZeroMinute | j<<stdArgShift, layout[i+j:]
 			}
 		case 's':
 			j := 1
@@ -122,9 +161,9 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			}
 			switch j {
 			case 1: // d
-				return layout[0:i], stdSecond, layout[i+j:]
+				return layout[0:i], stdSecond | j<<stdArgShift, layout[i+j:]
 			default:
-				return layout[0:i], stdZeroSecond, layout[i+j:]
+				return layout[0:i], stdZeroSecond | j<<stdArgShift, layout[i+j:]
 			}
 		case 'd':
 			j := 1
@@ -135,9 +174,9 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			}
 			switch j {
 			case 1: // d
-				return layout[0:i], stdDay, layout[i+j:]
+				return layout[0:i], stdDay | j<<stdArgShift, layout[i+j:]
 			default:
-				return layout[0:i], stdZeroDay, layout[i+j:]
+				return layout[0:i], stdZeroDay | j<<stdArgShift, layout[i+j:]
 			}
 		case 'E':
 			j := 1
@@ -162,9 +201,9 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 
 			switch j {
 			case 1: // d
-				return layout[0:i], stdNumMonth, layout[i+j:]
+				return layout[0:i], stdNumMonth | j<<stdArgShift, layout[i+j:]
 			case 2:
-				return layout[0:i], stdZeroMonth, layout[i+j:]
+				return layout[0:i], stdZeroMonth | j<<stdArgShift, layout[i+j:]
 			case 3:
 				return layout[0:i], stdMonth, layout[i+j:]
 			case 4:
@@ -172,19 +211,59 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			}
 
 			i = i + j - 1
-		case 'Y', 'y', 'x':
+		case 'Y', 'y':
 			j := 1
 			for ; i+j < layoutLength; j++ {
 				if layout[i+j] != r {
 					break
 				}
 			}
+			era := 0
+			if r == 'Y' {
+				era = stdYearOfEra
+			}
 			switch j {
 			case 2: // d
-				return layout[0:i], stdYear, layout[i+j:]
+				return layout[0:i], stdYear | j<<stdArgShift | era, layout[i+j:]
 			default: // dd
-				return layout[0:i], stdLongYear, layout[i+j:]
+				return layout[0:i], stdLongYear | j<<stdArgShift | era, layout[i+j:]
+			}
+		case 'G':
+			j := 1
+			for ; i+j < layoutLength; j++ {
+				if layout[i+j] != r {
+					break
+				}
+			}
+			return layout[0:i], stdEra, layout[i+j:]
+		case 'K', 'k':
+			j := 1
+			for ; i+j < layoutLength; j++ {
+				if layout[i+j] != r {
+					break
+				}
+			}
+			if r == 'K' {
+				return layout[0:i], stdHour11 | j<<stdArgShift, layout[i+j:]
 			}
+			return layout[0:i], stdHour24 | j<<stdArgShift, layout[i+j:]
+		case 'x', 'D', 'w', 'e':
+			j := 1
+			for ; i+j < layoutLength; j++ {
+				if layout[i+j] != r {
+					break
+				}
+			}
+			std := stdWeekYear
+			switch r {
+			case 'D':
+				std = stdYearDay
+			case 'w':
+				std = stdWeek
+			case 'e':
+				std = stdISOWeekDay
+			}
+			return layout[0:i], std | j<<stdArgShift, layout[i+j:]
 		case 'S':
 			j := 1
 			for ; i+j < layoutLength; j++ {
@@ -199,6 +278,25 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 			default:
 				return layout[0:i], stdFracSecond9 | (j << stdArgShift), layout[i+j:]
 			}
+		case 'X':
+			j := 1
+			for ; i+j < layoutLength; j++ {
+				if layout[i+j] != r {
+					break
+				}
+			}
+
+			// The argument is the number of decimal digits of the unit.
+			unit := 0
+			switch {
+			case j >= 9:
+				unit = 9
+			case j >= 6:
+				unit = 6
+			case j >= 3:
+				unit = 3
+			}
+			return layout[0:i], stdEpoch | (unit << stdArgShift), layout[i+j:]
 		case 'a':
 			j := 1
 			for ; i+j < layoutLength; j++ {
@@ -216,38 +314,46 @@ func nextStdChunk(layout string) (prefix string, std int, suffix string) {
 				}
 			}
 
+			// Joda prints a zero offset as a number, but accepts "Z" too.
 			switch j {
 			case 1: // d
-				return layout[0:i], stdNumTZ, layout[i+j:]
+				return layout[0:i], stdNumTZ | stdZeroZ, layout[i+j:]
 			case 2: // d
-				return layout[0:i], stdNumColonTZ, layout[i+j:]
+				return layout[0:i], stdNumColonTZ | stdZeroZ, layout[i+j:]
 			default: // time zone id
 				return layout[0:i], stdTZ, layout[i+j:]
 			}
 		case '\'': // ' (text delimiter)  or '' (real quote)
+			layout, i = unquote(layout, i)
+			layoutLength = len(layout)
+		}
+	}
+	return layout, 0, ""
+}
 
-			// real quote
-			if i+1 < layoutLength && layout[i+1] == r {
-				layout = layout[0:i] + layout[i+1:]
-				layoutLength = layoutLength - 1
+// unquote removes the quotes around the literal text that starts with the
+// quote at layout[i], and returns the new layout and the index of the last
+// byte of the text. Two quotes in a row stand for a quote, inside literal
+// text too; a quote that is not closed quotes the rest of the layout.
+func unquote(layout string, i int) (string, int) {
+	if i+1 < len(layout) && layout[i+1] == '\'' {
+		return layout[:i] + layout[i+1:], i
+	}
+	text := make([]byte, 0, len(layout)-i)
+	j := i + 1
+	for ; j < len(layout); j++ {
+		if layout[j] == '\'' {
+			if j+1 < len(layout) && layout[j+1] == '\'' {
+				text = append(text, '\'')
+				j++
 				continue
 			}
-
-			tmp := []byte{}
-			j := 1
-			for ; i+j < layoutLength; j++ {
-				if layout[i+j] != r {
-					tmp = append(tmp, layout[i+j])
-					continue
-				}
-				break
-			}
-			layout = layout[0:i] + string(tmp) + layout[i+j+1:]
-			i = i + j - 2
-			layoutLength = layoutLength - 2
+			j++
+			break
 		}
+		text = append(text, layout[j])
 	}
-	return layout, 0, ""
+	return layout[:i] + string(text) + layout[j:], i + len(text) - 1
 }
 
 var longDayNames = []string{
@@ -300,6 +406,34 @@ var longMonthNames = []string{
 	"December",
 }
 
+var narrowMonthNames = []string{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"}
+
+var narrowDayNames = []string{"S", "M", "T", "W", "T", "F", "S"}
+
+var longQuarterNames = []string{
+	"1st quarter",
+	"2nd quarter",
+	"3rd quarter",
+	"4th quarter",
+}
+
+var (
+	shortEraNames  = []string{"BC", "AD"}
+	longEraNames   = []string{"Before Christ", "Anno Domini"}
+	narrowEraNames = []string{"B", "A"}
+)
+
+// eraNames returns the names of BC and AD for the number of pattern letters.
+func eraNames(width int) []string {
+	switch width {
+	case 4:
+		return longEraNames
+	case 5:
+		return narrowEraNames
+	}
+	return shortEraNames
+}
+
 // match reports whether s1 and s2 match ignoring case.
 // It is assumed s1 and s2 are the same length.
 func match(s1, s2 string) bool {
@@ -410,7 +544,12 @@ func formatNano(b []byte, nanosec uint, n int, trim bool) []byte {
 //
 // Predefined layouts RFC3339 and others describe standard
 // and convenient representations of the reference time.
-func Format(t time.Time, layout string) string {
+func Format(t time.Time, layout string, opts ...Option) string {
+	o := newOptions(opts)
+	return format(t, layout, &o)
+}
+
+func format(t time.Time, layout string, o *options) string {
 	const bufSize = 64
 	var b []byte
 	max := len(layout) + 10
@@ -420,37 +559,56 @@ func Format(t time.Time, layout string) string {
 	} else {
 		b = make([]byte, 0, max)
 	}
-	b = AppendFormat(t, b, layout)
+	b = appendFormat(t, b, layout, o)
 	return string(b)
 }
 
 // AppendFormat is like Format but appends the textual
 // representation to b and returns the extended buffer.
-func AppendFormat(t time.Time, b []byte, layout string) []byte {
+func AppendFormat(t time.Time, b []byte, layout string, opts ...Option) []byte {
+	o := newOptions(opts)
+	return appendFormat(t, b, layout, &o)
+}
+
+func appendFormat(t time.Time, b []byte, layout string, o *options) []byte {
+	layout = o.dialect.expand(layout)
+	if o.zone != nil {
+		t = t.In(o.zone)
+	}
+	if o.rounding > RoundTruncate {
+		// Round before the fields are taken apart, so that a carry
+		// reaches the seconds, minutes and the date. A layout without
+		// fractional seconds is left alone.
+		if width := o.dialect.fractionWidth(layout); width > 0 {
+			t = o.rounding.round(t, width)
+		}
+	}
 	var (
 		name, offset, abs = locabs(t)
 
 		year  int = -1
 		month time.Month
 		day   int
+		yday  int
 		hour  int = -1
 		min   int
 		sec   int
 	)
 	// Each iteration generates one std value.
 	for layout != "" {
-		prefix, std, suffix := nextStdChunk(layout)
+		prefix, std, suffix := o.dialect.next(layout)
 		if prefix != "" {
 			b = append(b, prefix...)
 		}
 		if std == 0 {
 			break
 		}
+		chunk := layout
 		layout = suffix
 
 		// Compute year, month, day if needed.
 		if year < 0 && std&stdNeedDate != 0 {
-			year, month, day, _ = absDate(abs, true)
+			year, month, day, yday = absDate(abs, true)
 		}
 
 		// Compute hour, minute, second if needed.
@@ -458,62 +616,113 @@ func AppendFormat(t time.Time, b []byte, layout string) []byte {
 			hour, min, sec = absClock(abs)
 		}
 
-		switch std & stdMask {
+		// The number of pattern letters is the minimum width of a number.
+		width := std >> stdArgShift & stdWidthMask
+		flags := std
+		std &= stdMask
+		start := len(b)
+
+		switch std {
 		case stdYear:
 			y := year
+			if flags&stdYearOfEra != 0 && y <= 0 {
+				y = 1 - y
+			}
 			if y < 0 {
 				y = -y
 			}
 			b = appendInt(b, y%100, 2)
 		case stdLongYear:
-			b = appendInt(b, year, 4)
+			y := year
+			if flags&stdYearOfEra != 0 && y <= 0 {
+				y = 1 - y
+			}
+			if o.yearDigits > 0 {
+				if y >= 0 {
+					b = append(b, '+')
+				}
+				if width < o.yearDigits {
+					width = o.yearDigits
+				}
+			} else if flags&stdSignPad != 0 && y > 0 && len(appendInt(nil, y, 0)) > width {
+				b = append(b, '+')
+			}
+			b = appendInt(b, y, width)
+		case stdEra:
+			if year > 0 {
+				b = append(b, eraNames(width)[1]...)
+			} else {
+				b = append(b, eraNames(width)[0]...)
+			}
+		case stdQuarter:
+			b = appendInt(b, (int(month)+2)/3, width)
+		case stdQuarterText:
+			q := (int(month) + 2) / 3
+			if width == 4 {
+				b = append(b, longQuarterNames[q-1]...)
+			} else {
+				b = append(b, 'Q')
+				b = appendInt(b, q, 0)
+			}
 		case stdMonth:
+			if width == 5 {
+				b = append(b, month.String()[:1]...)
+				break
+			}
 			b = append(b, month.String()[:3]...)
 		case stdLongMonth:
 			m := month.String()
 			b = append(b, m...)
-		case stdNumMonth:
-			b = appendInt(b, int(month), 0)
-		case stdZeroMonth:
-			b = appendInt(b, int(month), 2)
+		case stdNumMonth, stdZeroMonth:
+			b = appendInt(b, int(month), width)
 		case stdWeekDay:
-			b = append(b, absWeekday(abs).String()[:3]...)
+			switch width {
+			case 5:
+				b = append(b, absWeekday(abs).String()[:1]...)
+			case 2:
+				b = append(b, absWeekday(abs).String()[:2]...)
+			default:
+				b = append(b, absWeekday(abs).String()[:3]...)
+			}
 		case stdLongWeekDay:
 			s := absWeekday(abs).String()
 			b = append(b, s...)
 		case stdDay:
-			b = appendInt(b, day, 0)
+			b = appendInt(b, day, width)
 		case stdUnderDay:
 			if day < 10 {
 				b = append(b, ' ')
 			}
 			b = appendInt(b, day, 0)
 		case stdZeroDay:
-			b = appendInt(b, day, 2)
+			b = appendInt(b, day, width)
 		case stdHour:
-			b = appendInt(b, hour, 2)
-		case stdHour12:
+			b = appendInt(b, hour, width)
+		case stdHour12, stdZeroHour12:
 			// Noon is 12PM, midnight is 12AM.
 			hr := hour % 12
 			if hr == 0 {
 				hr = 12
 			}
-			b = appendInt(b, hr, 0)
-		case stdZeroHour12:
-			// Noon is 12PM, midnight is 12AM.
-			hr := hour % 12
+			b = appendInt(b, hr, width)
+		case stdHour11:
+			b = appendInt(b, hour%12, width)
+		case stdHour24:
+			hr := hour
 			if hr == 0 {
-				hr = 12
-			}
-			b = appendInt(b, hr, 2)
-		case stdMinute:
-			b = appendInt(b, min, 0)
-		case stdZeroMinute:
-			b = appendInt(b, min, 2)
-		case stdSecond:
-			b = appendInt(b, sec, 0)
-		case stdZeroSecond:
-			b = appendInt(b, sec, 2)
+				hr = 24
+			}
+			b = appendInt(b, hr, width)
+		case stdMilliOfDay:
+			b = appendInt(b, ((hour*60+min)*60+sec)*1000+t.Nanosecond()/1e6, width)
+		case stdNanoOfDay:
+			b = appendInt(b, ((hour*60+min)*60+sec)*1e9+t.Nanosecond(), width)
+		case stdNanoSecond:
+			b = appendInt(b, t.Nanosecond(), width)
+		case stdMinute, stdZeroMinute:
+			b = appendInt(b, min, width)
+		case stdSecond, stdZeroSecond:
+			b = appendInt(b, sec, width)
 		case stdPM:
 			if hour >= 12 {
 				b = append(b, "PM"...)
@@ -529,7 +738,7 @@ func AppendFormat(t time.Time, b []byte, layout string) []byte {
 		case stdISO8601TZ, stdISO8601ColonTZ, stdISO8601SecondsTZ, stdISO8601ShortTZ, stdISO8601ColonSecondsTZ, stdNumTZ, stdNumColonTZ, stdNumSecondsTz, stdNumShortTZ, stdNumColonSecondsTZ:
 			// Ugly special case. We cheat and take the "Z" variants
 			// to mean "the time zone as formatted for ISO 8601".
-			if offset == 0 && (std == stdISO8601TZ || std == stdISO8601ColonTZ || std == stdISO8601SecondsTZ || std == stdISO8601ShortTZ || std == stdISO8601ColonSecondsTZ) {
+			if offset == 0 && (std == stdISO8601TZ || std == stdISO8601ColonTZ || std == stdISO8601SecondsTZ || std == stdISO8601ShortTZ || std == stdISO8601ColonSecondsTZ || o.zeroOffsetZ && flags&stdZeroZ != 0) {
 				b = append(b, 'Z')
 				break
 			}
@@ -546,12 +755,12 @@ func AppendFormat(t time.Time, b []byte, layout string) []byte {
 			if std == stdISO8601ColonTZ || std == stdNumColonTZ || std == stdISO8601ColonSecondsTZ || std == stdNumColonSecondsTZ {
 				b = append(b, ':')
 			}
-			if std != stdNumShortTZ && std != stdISO8601ShortTZ {
+			if std != stdNumShortTZ && std != stdISO8601ShortTZ || flags&stdOptional != 0 && zone%60 != 0 {
 				b = appendInt(b, zone%60, 2)
 			}
 
 			// append seconds if appropriate
-			if std == stdISO8601SecondsTZ || std == stdNumSecondsTz || std == stdNumColonSecondsTZ || std == stdISO8601ColonSecondsTZ {
+			if (std == stdISO8601SecondsTZ || std == stdNumSecondsTz || std == stdNumColonSecondsTZ || std == stdISO8601ColonSecondsTZ) && (flags&stdOptional == 0 || absoffset%60 != 0) {
 				if std == stdNumColonSecondsTZ || std == stdISO8601ColonSecondsTZ {
 					b = append(b, ':')
 				}
@@ -574,13 +783,141 @@ func AppendFormat(t time.Time, b []byte, layout string) []byte {
 			}
 			b = appendInt(b, zone/60, 2)
 			b = appendInt(b, zone%60, 2)
+		case stdZoneID:
+			if id := t.Location().String(); id != "" && id != "Local" {
+				b = append(b, id...)
+				break
+			}
+			// The location has no name; use its offset instead.
+			if offset == 0 {
+				b = append(b, 'Z')
+				break
+			}
+			b = appendOffset(b, offset, true)
+		case stdGMTOffset:
+			b = append(b, "GMT"...)
+			if offset != 0 {
+				b = appendOffset(b, offset, width == 4)
+			}
 		case stdFracSecond0, stdFracSecond9:
-			b = formatNano(b, uint(t.Nanosecond()), std>>stdArgShift, std&stdMask == stdFracSecond9)
+			b = formatNano(b, uint(t.Nanosecond()), width, std == stdFracSecond9)
+		case stdYearDay:
+			b = appendInt(b, yday+1, width)
+		case stdWeekYear:
+			y, _ := t.ISOWeek()
+			if flags&stdUSWeek != 0 {
+				y, _ = usWeek(t.Year(), t.YearDay()-1, t.Weekday())
+			}
+			if width == 2 {
+				if y < 0 {
+					y = -y
+				}
+				b = appendInt(b, y%100, 2)
+				break
+			}
+			b = appendInt(b, y, width)
+		case stdWeek:
+			_, w := t.ISOWeek()
+			if flags&stdUSWeek != 0 {
+				_, w = usWeek(t.Year(), t.YearDay()-1, t.Weekday())
+			}
+			b = appendInt(b, w, width)
+		case stdISOWeekDay:
+			wd := int(absWeekday(abs))
+			if wd == 0 {
+				wd = 7
+			}
+			b = appendInt(b, wd, width)
+		case stdWeekOfMonth:
+			wd, w := firstWeek(year, int(month))
+			if flags&stdUSWeek != 0 {
+				wd, w = (int(absWeekday(abs))-day%7+8)%7, 1
+			}
+			b = appendInt(b, (day-1+wd)/7+w, width)
+		case stdWeekDayInMonth:
+			b = appendInt(b, (day-1)/7+1, width)
+		case stdMillisecond:
+			b = appendInt(b, t.Nanosecond()/1e6, width)
+		case stdNumWeekDay:
+			b = appendInt(b, int(absWeekday(abs)), width)
+		case stdSundayWeek:
+			b = appendInt(b, (yday+7-int(absWeekday(abs)))/7, width)
+		case stdMondayWeek:
+			b = appendInt(b, (yday+7-(int(absWeekday(abs))+6)%7)/7, width)
+		case stdEpoch:
+			// Whole units, rounded down like Unix does for seconds.
+			switch width {
+			case 0:
+				b = appendInt(b, int(t.Unix()), 0)
+			case 3:
+				b = appendInt(b, int(t.UnixMilli()), 0)
+			case 6:
+				b = appendInt(b, int(t.UnixMicro()), 0)
+			default:
+				b = appendInt(b, int(t.UnixNano()), 0)
+			}
+		case stdUnsupported:
+			// The letters as they are, which parsing rejects.
+			b = append(b, o.dialect.stdText(chunk, suffix)...)
+		}
+		if flags&stdOrdinal != 0 {
+			n, _ := atoi(string(b[start:]))
+			b = append(b, ordinalSuffix(n)...)
+		}
+		if flags&stdSpacePad != 0 {
+			for i := start; i < len(b)-1 && b[i] == '0'; i++ {
+				b[i] = ' '
+			}
 		}
 	}
 	return b
 }
 
+// appendOffset appends a signed offset from UTC in hours, then minutes and
+// seconds when not zero, to b. Hours and minutes have two digits when long.
+func appendOffset(b []byte, offset int, long bool) []byte {
+	if offset < 0 {
+		b = append(b, '-')
+		offset = -offset
+	} else {
+		b = append(b, '+')
+	}
+	if long {
+		b = appendInt(b, offset/3600, 2)
+	} else {
+		b = appendInt(b, offset/3600, 0)
+	}
+	if long || offset/60%60 != 0 || offset%60 != 0 {
+		b = append(b, ':')
+		b = appendInt(b, offset/60%60, 2)
+	}
+	if offset%60 != 0 {
+		b = append(b, ':')
+		b = appendInt(b, offset%60, 2)
+	}
+	return b
+}
+
+// fractionWidth returns the largest number of fractional second digits
+// in layout, at most nine.
+func (d Dialect) fractionWidth(layout string) int {
+	width := 0
+	for layout != "" {
+		_, std, suffix := d.next(layout)
+		if std == 0 {
+			break
+		}
+		if s := std & stdMask; (s == stdFracSecond0 || s == stdFracSecond9) && std>>stdArgShift&stdWidthMask > width {
+			width = std >> stdArgShift & stdWidthMask
+		}
+		layout = suffix
+	}
+	if width > 9 {
+		width = 9
+	}
+	return width
+}
+
 // isDigit reports whether s[i] is in range and is a decimal digit.
 func isDigit(s string, i int) bool {
 	if len(s) <= i {
@@ -590,6 +927,11 @@ func isDigit(s string, i int) bool {
 	return '0' <= c && c <= '9'
 }
 
+// isLetter reports whether c is an ASCII letter.
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
 var errBad = errors.New("bad value for field") // placeholder not passed to user
 
 // getnum parses s[0:1] or s[0:2] (fixed forces the latter)
@@ -608,6 +950,92 @@ func getnum(s string, fixed bool) (int, string, error) {
 	return int(s[0]-'0')*10 + int(s[1]-'0'), s[2:], nil
 }
 
+// getsigned parses an optionally signed decimal integer of one up to max
+// digits and returns the integer and the remainder of the string.
+func getsigned(s string, max int) (int, string, error) {
+	i := 0
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		i = 1
+	}
+	j := i
+	for j-i < max && isDigit(s, j) {
+		j++
+	}
+	if j == i {
+		return 0, s, errBad
+	}
+	x, err := atoi(s[:j])
+	return x, s[j:], err
+}
+
+// isNumber reports whether std is a numeric field.
+func isNumber(std int) bool {
+	switch std & stdMask {
+	case stdNumMonth, stdZeroMonth, stdDay, stdUnderDay, stdZeroDay,
+		stdHour, stdHour12, stdZeroHour12, stdMinute, stdZeroMinute,
+		stdSecond, stdZeroSecond, stdLongYear, stdYear,
+		stdFracSecond0, stdFracSecond9, stdEpoch,
+		stdYearDay, stdWeekYear, stdWeek, stdISOWeekDay,
+		stdQuarter, stdHour11, stdHour24, stdMilliOfDay, stdNanoOfDay, stdNanoSecond,
+		stdWeekOfMonth, stdWeekDayInMonth, stdMillisecond,
+		stdNumWeekDay, stdSundayWeek, stdMondayWeek:
+		return true
+	}
+	return false
+}
+
+// reservedDigits returns the number of digits needed by the run of numeric
+// fields layout starts with, that is not separated by any text. Each field
+// takes as many digits as it has pattern letters, and two-digit years two.
+func (d Dialect) reservedDigits(layout string) int {
+	n := 0
+	for {
+		prefix, std, suffix := d.next(layout)
+		if prefix != "" || !isNumber(std) {
+			return n
+		}
+		width := std >> stdArgShift & stdWidthMask
+		if s := std & stdMask; s == stdYear || s == stdWeekYear && width == 2 {
+			n += 2
+		} else if width > 0 {
+			n += width
+		} else {
+			n++
+		}
+		layout = suffix
+	}
+}
+
+// getnumWidth is like getnum for a field printed with at least width digits.
+// It parses exactly width digits when fixed, otherwise up to max(width, 2).
+// A width of zero parses any number of digits, as lenient dialects do.
+func getnumWidth(s string, width int, fixed bool) (int, string, error) {
+	if width == 0 {
+		return getdigits(s, 1, 9)
+	}
+	if width <= 2 {
+		return getnum(s, fixed)
+	}
+	if fixed {
+		return getdigits(s, width, width)
+	}
+	return getdigits(s, 1, width)
+}
+
+// getdigits parses at least min and at most max decimal digits
+// and returns the integer and the remainder of the string.
+func getdigits(s string, min, max int) (int, string, error) {
+	n := 0
+	for n < max && isDigit(s, n) {
+		n++
+	}
+	if n == 0 || n < min {
+		return 0, s, errBad
+	}
+	x, err := atoi(s[:n])
+	return x, s[n:], err
+}
+
 func cutspace(s string) string {
 	for len(s) > 0 && s[0] == ' ' {
 		s = s[1:]
@@ -638,8 +1066,9 @@ func skip(value, prefix string) (string, error) {
 
 // Parse parses time string with joda format:
 // http://joda-time.sourceforge.net/apidocs/org/joda/time/format/DateTimeFormat.html
-func Parse(layout, value string) (time.Time, error) {
-	return parse(layout, value, time.UTC, time.Local)
+func Parse(layout, value string, opts ...Option) (time.Time, error) {
+	o := newOptions(opts)
+	return parse(layout, value, time.UTC, time.Local, &o, nil)
 }
 
 // ParseInLocation is like Parse but differs in two important ways.
@@ -647,12 +1076,45 @@ func Parse(layout, value string) (time.Time, error) {
 // ParseInLocation interprets the time as in the given location.
 // Second, when given a zone offset or abbreviation, Parse tries to match it
 // against the Local location; ParseInLocation uses the given location.
-func ParseInLocation(layout, value string, loc *time.Location) (time.Time, error) {
-	return parse(layout, value, loc, loc)
+func ParseInLocation(layout, value string, loc *time.Location, opts ...Option) (time.Time, error) {
+	o := newOptions(opts)
+	return parse(layout, value, loc, loc, &o, nil)
+}
+
+// ParsePrefix is like Parse but parses only the beginning of value, like
+// Joda's parseInto, and returns the number of bytes it consumed. The text
+// after the time is left for the caller, as in
+// "2024-05-01 10:00:00,123 INFO started".
+func ParsePrefix(layout, value string, opts ...Option) (t time.Time, n int, err error) {
+	o := newOptions(opts)
+	return parsePrefix(layout, value, time.UTC, time.Local, &o)
+}
+
+func parsePrefix(layout, value string, defaultLocation, local *time.Location, o *options) (time.Time, int, error) {
+	var rest string
+	t, err := parse(layout, value, defaultLocation, local, o, &rest)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return t, len(value) - len(rest), nil
 }
 
-func parse(layout, value string, defaultLocation, local *time.Location) (time.Time, error) {
+// parse parses value, or with a non-nil rest only the beginning of it,
+// leaving the text that follows in rest.
+func parse(layout, value string, defaultLocation, local *time.Location, o *options, rest *string) (time.Time, error) {
+	if o.zone == nil {
+		return parseTime(layout, value, defaultLocation, local, o, rest)
+	}
+	t, err := parseTime(layout, value, o.zone, local, o, rest)
+	if err == nil && !o.offsetParsed {
+		t = t.In(o.zone)
+	}
+	return t, err
+}
+
+func parseTime(layout, value string, defaultLocation, local *time.Location, o *options, rest *string) (time.Time, error) {
 	alayout, avalue := layout, value
+	layout = o.dialect.expand(layout)
 	rangeErrString := "" // set if a value is out of range
 	amSet := false       // do we need to subtract 12 from the hour for midnight?
 	pmSet := false       // do we need to add 12 to the hour?
@@ -669,96 +1131,242 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 		z          *time.Location
 		zoneOffset int = -1
 		zoneName   string
+		epochSet   bool // the instant is given by an epoch
+		epochSec   int64
+		epochNsec  int64
+		yday       int  = -1 // day of year, if given
+		weekSet    bool      // the date is given by an ISO week date
+		usWeeks    bool      // weeks are those of the US locale
+		weekYear   int
+		week       int  = 1
+		weekDay    int  = 1
+		weekDaySet bool      // weekDay is given
+		monthWeek  int  = -1 // week of month, if given
+		dayInMonth int  = -1 // day of week in month, if given
+		yearWeek   int  = -1 // week of year from the first Sunday or Monday, if given
+		sundayWeek bool      // yearWeek starts on Sunday
+		bc         bool      // the era is before Christ
+		yearOfEra  bool      // the year counts from 1 in its era
+		dayAt      int  = -1 // offset of the day of month, if given
+		ydayAt     int  = -1 // offset of the day of year, if given
+		weekAt     int  = -1 // offset of the week, if given
+		zoneAt     int  = -1 // offset of the zone abbreviation, if given
+		hourAt     int  = -1 // offset of the hour, if given
+		dayElem    string
+		ydayElem   string
+		weekElem   string
+		zoneElem   string
+		hourElem   string
+		hourField  string
 	)
 
+	lenient := o.dialect.lenient()
+
 	// Each iteration processes one std value.
 	for {
 		var err error
-		prefix, std, suffix := nextStdChunk(layout)
-		stdstr := layout[len(prefix) : len(layout)-len(suffix)]
+		prefix, std, suffix := o.dialect.next(layout)
 		value, err = skip(value, prefix)
 		if err != nil {
-			return time.Time{}, &time.ParseError{alayout, avalue, prefix, value, ""}
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, LayoutElem: prefix, ValueElem: value},
+				Offset:     len(avalue) - len(value),
+				Expected:   string(appendJodaLiteral(nil, prefix)),
+				Reason:     missingOr(value, ReasonBadText),
+			}
 		}
 		if std == 0 {
-			if len(value) != 0 {
-				return time.Time{}, &time.ParseError{alayout, avalue, "", value, ": extra text: " + value}
+			if rest != nil {
+				*rest = value
+			} else if len(value) != 0 {
+				return time.Time{}, &ParseError{
+					ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: value, Message: ": extra text: " + value},
+					Offset:     len(avalue) - len(value),
+					Reason:     ReasonExtraText,
+				}
 			}
 			break
 		}
+		stdstr := o.dialect.stdText(layout, suffix)
+		expected := o.dialect.jodaElem(std, stdstr)
 		layout = suffix
 		var p string
-		switch std & stdMask {
+		width := std >> stdArgShift & stdWidthMask
+		flags := std
+		std &= stdMask
+
+		if flags&stdSpacePad != 0 {
+			for len(value) > 1 && value[0] == ' ' {
+				value = value[1:]
+			}
+		}
+
+		// In a run of numbers that are not separated by any text, like
+		// yyyyMMdd, hide the digits needed by the fields that follow.
+		start := len(avalue) - len(value)
+		full, cut := value, len(value)
+		if isNumber(std) {
+			if reserved := o.dialect.reservedDigits(layout); reserved > 0 {
+				i := 0
+				if (std == stdLongYear || std == stdEpoch) && value != "" && (value[0] == '-' || value[0] == '+') {
+					i++
+				}
+				j := i
+				for isDigit(value, j) {
+					j++
+				}
+				if j-i > reserved {
+					cut = j - reserved
+					value = value[:cut]
+				}
+			} else if lenient {
+				// Any number of digits.
+				width = 0
+			}
+		}
+
+		switch std {
 		case stdYear:
 			if len(value) < 2 {
 				err = errBad
 				break
 			}
+			if flags&stdBaseNow != 0 {
+				year, value, err = getTwoDigitYear(value, o.twoDigitYearStart())
+				yearOfEra = true
+				break
+			}
 			p, value = value[0:2], value[2:]
 			year, err = atoi(p)
-			if year >= 69 { // Unix time starts Dec 31 1969 in some time zones
+			if year >= 69 && flags&stdBase2000 == 0 { // Unix time starts Dec 31 1969 in some time zones
 				year += 1900
 			} else {
 				year += 2000
 			}
+			yearOfEra = flags&stdYearOfEra != 0
 		case stdLongYear:
-			if len(value) < 4 || !isDigit(value, 0) {
+			// A signed year of any length, as in ISO 8601 expanded
+			// representations like "+012345" or "-0044".
+			if flags&stdBaseNow != 0 {
+				year, value, err = getTwoDigitYear(value, o.twoDigitYearStart())
+			} else {
+				year, value, err = getsigned(value, 9)
+			}
+			yearOfEra = flags&stdYearOfEra != 0
+		case stdEra:
+			var i int
+			i, value, err = lookup(eraNames(width), value)
+			bc = i == 0
+		case stdQuarter, stdQuarterText:
+			// Ignore the quarter except for error checking.
+			var q int
+			switch {
+			case std == stdQuarter:
+				q, value, err = getnumWidth(value, width, width > 1)
+			case width == 4:
+				q, value, err = lookup(longQuarterNames, value)
+				q++
+			case len(value) >= 2 && value[0] == 'Q':
+				q, value, err = getnum(value[1:], false)
+			default:
 				err = errBad
-				break
 			}
-			p, value = value[0:4], value[4:]
-			year, err = atoi(p)
+			if q < 1 || 4 < q {
+				rangeErrString = "quarter"
+			}
 		case stdMonth:
+			if width == 5 {
+				// Narrow names are ambiguous; take the first month.
+				month, value, err = lookup(narrowMonthNames, value)
+				month++
+				break
+			}
 			month, value, err = lookup(shortMonthNames, value)
 			month++
 		case stdLongMonth:
 			month, value, err = lookup(longMonthNames, value)
 			month++
 		case stdNumMonth, stdZeroMonth:
-			month, value, err = getnum(value, std == stdZeroMonth)
+			month, value, err = getnumWidth(value, width, std == stdZeroMonth)
 			if month <= 0 || 12 < month {
 				rangeErrString = "month"
 			}
-		case stdWeekDay:
-			// Ignore weekday except for error checking.
-			_, value, err = lookup(shortDayNames, value)
-		case stdLongWeekDay:
-			_, value, err = lookup(longDayNames, value)
+		case stdWeekDay, stdLongWeekDay:
+			// The weekday only matters to week dates.
+			names := shortDayNames
+			if std == stdLongWeekDay {
+				names = longDayNames
+			} else if width == 5 {
+				names = narrowDayNames
+			} else if width == 2 {
+				names = minDayNames
+			}
+			var wd int
+			wd, value, err = lookup(names, value)
+			weekDay, weekDaySet = (wd+6)%7+1, true
 		case stdDay, stdUnderDay, stdZeroDay:
 			if std == stdUnderDay && len(value) > 0 && value[0] == ' ' {
 				value = value[1:]
 			}
-			day, value, err = getnum(value, std == stdZeroDay)
+			day, value, err = getnumWidth(value, width, std == stdZeroDay)
+			dayAt, dayElem = start, expected
 			if day < 0 {
 				// Note that we allow any one- or two-digit day here.
 				rangeErrString = "day"
 			}
 		case stdHour:
-			hour, value, err = getnum(value, false)
+			hour, value, err = getnumWidth(value, width, false)
 			if hour < 0 || 24 <= hour {
 				rangeErrString = "hour"
 			}
 		case stdHour12, stdZeroHour12:
-			hour, value, err = getnum(value, std == stdZeroHour12)
+			hour, value, err = getnumWidth(value, width, std == stdZeroHour12)
 			if hour < 0 || 12 < hour {
 				rangeErrString = "hour"
 			}
+		case stdHour11:
+			hour, value, err = getnumWidth(value, width, width > 1)
+			if hour < 0 || 11 < hour {
+				rangeErrString = "hour"
+			}
+		case stdHour24:
+			hour, value, err = getnumWidth(value, width, width > 1)
+			if hour < 1 || 24 < hour {
+				rangeErrString = "hour"
+			}
+			hour %= 24
+		case stdMilliOfDay:
+			var ms int
+			ms, value, err = getdigits(value, width, 8)
+			if ms < 0 || 86400000 <= ms {
+				rangeErrString = "millisecond of day"
+			}
+			hour, min, sec, nsec = ms/3600000, ms/60000%60, ms/1000%60, ms%1000*1e6
+		case stdNanoOfDay:
+			var ns int
+			ns, value, err = getdigits(value, width, 14)
+			if ns < 0 || 86400*1e9 <= ns {
+				rangeErrString = "nanosecond of day"
+			}
+			hour, min, sec, nsec = ns/(3600*1e9), ns/(60*1e9)%60, ns/1e9%60, ns%1e9
+		case stdNanoSecond:
+			nsec, value, err = getdigits(value, width, 9)
 		case stdMinute, stdZeroMinute:
-			min, value, err = getnum(value, std == stdZeroMinute)
+			min, value, err = getnumWidth(value, width, std == stdZeroMinute)
 			if min < 0 || 60 <= min {
 				rangeErrString = "minute"
 			}
 		case stdSecond, stdZeroSecond:
-			sec, value, err = getnum(value, std == stdZeroSecond)
+			sec, value, err = getnumWidth(value, width, std == stdZeroSecond)
 			if sec < 0 || 60 <= sec {
 				rangeErrString = "second"
 			}
 			// Special case: do we have a fractional second but no
 			// fractional second in the format?
 			if len(value) >= 2 && value[0] == '.' && isDigit(value, 1) {
-				_, std, _ = nextStdChunk(layout)
+				_, std, _ = o.dialect.next(layout)
 				std &= stdMask
-				if std == stdFracSecond0 || std == stdFracSecond9 {
+				if std == stdFracSecond0 || std == stdFracSecond9 || std == stdNanoSecond || std == stdMillisecond {
 					// Fractional second in the layout; proceed normally
 					break
 				}
@@ -766,7 +1374,7 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 				n := 2
 				for ; n < len(value) && isDigit(value, n); n++ {
 				}
-				nsec, rangeErrString, err = parseNanoseconds(value[1:], n-1) // remove first dot
+				nsec, rangeErrString, err = o.parseFraction(value[1:], n-1) // remove first dot
 				value = value[n:]
 			}
 		case stdPM:
@@ -774,11 +1382,12 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 				err = errBad
 				break
 			}
+			// In any case, as Joda parses text.
 			p, value = value[0:2], value[2:]
-			switch p {
-			case "PM":
+			switch {
+			case match(p, "PM"):
 				pmSet = true
-			case "AM":
+			case match(p, "AM"):
 				amSet = true
 			default:
 				err = errBad
@@ -798,15 +1407,35 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 				err = errBad
 			}
 		case stdISO8601TZ, stdISO8601ColonTZ, stdISO8601SecondsTZ, stdISO8601ShortTZ, stdISO8601ColonSecondsTZ, stdNumTZ, stdNumShortTZ, stdNumColonTZ, stdNumSecondsTz, stdNumColonSecondsTZ:
-			if (std == stdISO8601TZ || std == stdISO8601ShortTZ || std == stdISO8601ColonTZ) && len(value) >= 1 && value[0] == 'Z' {
+			if (std == stdISO8601TZ || std == stdISO8601ShortTZ || std == stdISO8601ColonTZ || std == stdISO8601SecondsTZ || std == stdISO8601ColonSecondsTZ || flags&stdZeroZ != 0) && len(value) >= 1 && value[0] == 'Z' {
 				value = value[1:]
 				z = time.UTC
 				break
 			}
+			if flags&stdOptional != 0 {
+				// The minutes or seconds may be left out when zero.
+				switch std {
+				case stdISO8601ShortTZ, stdNumShortTZ:
+					if isDigit(value, 3) && isDigit(value, 4) {
+						std = stdNumTZ
+					}
+				case stdISO8601SecondsTZ, stdNumSecondsTz:
+					if !isDigit(value, 5) || !isDigit(value, 6) {
+						std = stdNumTZ
+					}
+				case stdISO8601ColonSecondsTZ, stdNumColonSecondsTZ:
+					if len(value) < 9 || value[6] != ':' {
+						std = stdNumColonTZ
+					}
+				}
+			}
 			if std == stdNumTZ {
 				if len(value) == 3 {
 					// convert to short tz format
 					std = stdNumShortTZ
+				} else if o.dialect == Strftime && len(value) >= 6 && value[3] == ':' {
+					// %z accepts a colon, as in Python
+					std = stdNumColonTZ
 				}
 			}
 			var sign, hour, min, seconds string
@@ -878,11 +1507,46 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 				break
 			}
 			zoneName, value = value[:n], value[n:]
+			zoneAt, zoneElem = start, expected
+		case stdZoneID:
+			z, value, err = o.parseZoneID(value)
+		case stdUnsupported:
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, LayoutElem: stdstr, ValueElem: value, Message: ": unsupported pattern letters " + stdstr},
+				Offset:     start,
+				Expected:   stdstr,
+				Reason:     ReasonBadLayout,
+			}
+		case stdGMTOffset:
+			zoneOffset, value, err = parseGMTOffset(value, width == 4)
 
 		case stdFracSecond0:
+			if flags&stdVarDigits != 0 {
+				ndigit := 0
+				for ndigit < width && isDigit(value, ndigit) {
+					ndigit++
+				}
+				if ndigit == 0 {
+					err = errBad
+					break
+				}
+				nsec, rangeErrString, err = parseNanoseconds(value, ndigit)
+				value = value[ndigit:]
+				break
+			}
+			if o.fraction == FractionLenient {
+				ndigit := o.fractionDigits(value)
+				if ndigit == 0 {
+					err = errBad
+					break
+				}
+				nsec, rangeErrString, err = o.parseFraction(value, ndigit)
+				value = value[ndigit:]
+				break
+			}
 			// stdFracSecond0 requires the exact number of digits as specified in
 			// the layout.
-			ndigit := std >> stdArgShift
+			ndigit := width
 			if len(value) < ndigit {
 				err = errBad
 				break
@@ -890,6 +1554,90 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 			nsec, rangeErrString, err = parseNanoseconds(value, ndigit)
 			value = value[ndigit:]
 
+		case stdYearDay:
+			// Up to three digits, exactly as many as letters from DDD on.
+			if width < 3 {
+				yday, value, err = getnumWidth(value, 3, false)
+			} else {
+				yday, value, err = getnumWidth(value, width, true)
+			}
+			ydayAt, ydayElem = start, expected
+			if yday < 1 || 366 < yday {
+				rangeErrString = "day of year"
+			}
+		case stdWeekYear:
+			weekSet = true
+			usWeeks = flags&stdUSWeek != 0
+			if width == 2 {
+				weekYear, value, err = getnum(value, true)
+				weekYear += 2000
+				break
+			}
+			weekYear, value, err = getsigned(value, 9)
+		case stdWeek:
+			week, value, err = getnumWidth(value, width, width > 1)
+			weekAt, weekElem = start, expected
+			if week < 1 || 53 < week {
+				rangeErrString = "week"
+			}
+		case stdISOWeekDay:
+			weekDay, value, err = getnumWidth(value, width, width > 1)
+			if weekDay < 1 || 7 < weekDay {
+				rangeErrString = "day of week"
+			}
+			weekDaySet = true
+		case stdWeekOfMonth:
+			usWeeks = flags&stdUSWeek != 0
+			monthWeek, value, err = getnumWidth(value, width, false)
+			if monthWeek < 0 || 5 < monthWeek {
+				rangeErrString = "week of month"
+			}
+		case stdWeekDayInMonth:
+			dayInMonth, value, err = getnumWidth(value, width, false)
+			if dayInMonth < 1 || 5 < dayInMonth {
+				rangeErrString = "day of week in month"
+			}
+		case stdNumWeekDay:
+			var wd int
+			wd, value, err = getnumWidth(value, width, false)
+			if wd < 0 || 6 < wd {
+				rangeErrString = "day of week"
+			}
+			weekDay, weekDaySet = (wd+6)%7+1, true
+		case stdSundayWeek, stdMondayWeek:
+			yearWeek, value, err = getnumWidth(value, width, false)
+			if yearWeek < 0 || 53 < yearWeek {
+				rangeErrString = "week"
+			}
+			sundayWeek = std == stdSundayWeek
+		case stdMillisecond:
+			var ms int
+			ms, value, err = getnumWidth(value, width, true)
+			if ms < 0 || 1000 <= ms {
+				rangeErrString = "millisecond"
+			}
+			nsec = ms * 1e6
+		case stdEpoch:
+			neg := value != "" && value[0] == '-'
+			var n int
+			n, value, err = getsigned(value, 19)
+			if err != nil {
+				break
+			}
+			// A fraction of the unit, unless the layout has its own
+			// fractional second.
+			frac := ""
+			if len(value) >= 2 && value[0] == '.' && isDigit(value, 1) {
+				if _, next, _ := o.dialect.next(layout); next&stdMask != stdFracSecond0 && next&stdMask != stdFracSecond9 {
+					i := 1
+					for isDigit(value, i) {
+						i++
+					}
+					frac, value = value[1:i], value[i:]
+				}
+			}
+			epochSet = true
+			epochSec, epochNsec = epochTime(int64(n), width, frac, neg)
 		case stdFracSecond9:
 			if len(value) < 1 || value[0] < '0' || '9' < value[0] {
 				// Fractional second omitted.
@@ -897,64 +1645,229 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 			}
 			// Take any number of digits, even more than asked for,
 			// because it is what the stdSecond case would do.
-			i := 0
-			for i < 9 && i < len(value) && '0' <= value[i] && value[i] <= '9' {
-				i++
-			}
-			nsec, rangeErrString, err = parseNanoseconds(value, i)
+			i := o.fractionDigits(value)
+			nsec, rangeErrString, err = o.parseFraction(value, i)
 			value = value[i:]
 		}
-		if rangeErrString != "" {
-			return time.Time{}, &time.ParseError{alayout, avalue, stdstr, value, ": " + rangeErrString + " out of range"}
+		value = full[cut-len(value):]
+		switch std {
+		case stdHour, stdHour12, stdZeroHour12, stdHour11, stdHour24, stdMilliOfDay, stdNanoOfDay:
+			// Where the wall time is, for a daylight saving error.
+			hourAt, hourField, hourElem = start, fieldName(std), expected
+		}
+		if flags&stdOrdinal != 0 && err == nil {
+			// The suffix must be the one of the number, as in "1st".
+			n, _ := atoi(avalue[start : len(avalue)-len(value)])
+			if ord := ordinalSuffix(n); len(value) >= 2 && value[:2] == ord {
+				value = value[2:]
+			} else {
+				err = errBad
+			}
+		}
+		if rangeErrString != "" && !lenient {
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, LayoutElem: stdstr, ValueElem: value, Message: ": " + rangeErrString + " out of range"},
+				Field:      fieldName(std),
+				Offset:     start,
+				Expected:   expected,
+				Reason:     ReasonOutOfRange,
+			}
 		}
 		if err != nil {
-			return time.Time{}, &time.ParseError{alayout, avalue, stdstr, value, ""}
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, LayoutElem: stdstr, ValueElem: value},
+				Field:      fieldName(std),
+				Offset:     start,
+				Expected:   expected,
+				Reason:     missingOr(full, ReasonBadText),
+			}
 		}
 	}
+	if bc && yearOfEra {
+		year = 1 - year
+	}
 	if pmSet && hour < 12 {
 		hour += 12
 	} else if amSet && hour == 12 {
 		hour = 0
 	}
 
-	// Validate the day of the month.
-	if day < 1 || day > daysIn(time.Month(month), year) {
-		return time.Time{}, &time.ParseError{alayout, avalue, "", value, ": day out of range"}
+	if epochSet {
+		// An epoch is an instant; other fields than a fractional second
+		// and a time zone do not change it.
+		t := time.Unix(epochSec, epochNsec+int64(nsec))
+		switch {
+		case z != nil:
+			return t.In(z), nil
+		case zoneOffset != -1:
+			return o.offsetZone(t, zoneName, zoneOffset, local), nil
+		}
+		return t.In(defaultLocation), nil
+	}
+
+	if weekSet && usWeeks {
+		// Week 1 starts on the Sunday on or before January 1st, which is
+		// also the default weekday.
+		jan1 := time.Date(weekYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+		wd := weekDay % 7
+		if !weekDaySet {
+			wd = 0
+		}
+		t := jan1.AddDate(0, 0, (week-1)*7+wd-int(jan1.Weekday()))
+		year, month, day = t.Year(), int(t.Month()), t.Day()
+	} else if weekSet {
+		// Monday of week 1 is in the week of January 4th, and December
+		// 28th is in the last week.
+		if _, weeks := time.Date(weekYear, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek(); week > weeks && !lenient {
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: value, Message: ": week out of range"},
+				Field:      "week",
+				Offset:     weekAt,
+				Expected:   weekElem,
+				Reason:     ReasonOutOfRange,
+			}
+		}
+		jan4 := time.Date(weekYear, time.January, 4, 0, 0, 0, 0, time.UTC)
+		offset := (int(jan4.Weekday())+6)%7 - (week-1)*7 - (weekDay - 1)
+		t := jan4.AddDate(0, 0, -offset)
+		year, month, day = t.Year(), int(t.Month()), t.Day()
+	}
+	if yday != -1 {
+		if yday > 365 && !isLeap(year) {
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: value, Message: ": day of year out of range"},
+				Field:      "yearDay",
+				Offset:     ydayAt,
+				Expected:   ydayElem,
+				Reason:     ReasonOutOfRange,
+			}
+		}
+		t := time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC)
+		month, day = int(t.Month()), t.Day()
+	}
+
+	if yearWeek != -1 {
+		// Week 1 starts on the first Sunday or Monday of the year,
+		// which is also the default weekday.
+		first := int(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday())
+		wd := weekDay % 7
+		if !sundayWeek {
+			first, wd = (first+6)%7, weekDay-1
+		}
+		if !weekDaySet {
+			wd = 0
+		}
+		t := time.Date(year, time.January, 1+(7-first)%7+(yearWeek-1)*7+wd, 0, 0, 0, 0, time.UTC)
+		year, month, day = t.Year(), int(t.Month()), t.Day()
+	}
+
+	if monthWeek != -1 || dayInMonth != -1 {
+		// The weekday of a week of the month defaults to Monday, that of
+		// a day of week in the month to the weekday of the first.
+		first, w := firstWeek(year, month)
+		switch {
+		case monthWeek != -1 && usWeeks:
+			// Week 1 holds the first and starts on Sunday, the default.
+			wd := weekDay % 7
+			if !weekDaySet {
+				wd = 0
+			}
+			first = int(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Weekday())
+			day = (monthWeek-1)*7 + 1 + wd - first
+		case monthWeek != -1:
+			day = (monthWeek-w)*7 + weekDay - first
+		case weekDaySet:
+			day = (dayInMonth-1)*7 + 1 + (weekDay-1-first+7)%7
+		default:
+			day = (dayInMonth-1)*7 + 1
+		}
+	}
+
+	// Validate the day of the month; lenient dialects roll it over.
+	if !lenient && (day < 1 || day > daysIn(time.Month(month), year)) {
+		if dayAt == -1 {
+			// The day comes from other fields, such as a week.
+			dayAt = len(avalue) - len(value)
+		}
+		return time.Time{}, &ParseError{
+			ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: value, Message: ": day out of range"},
+			Field:      "day",
+			Offset:     dayAt,
+			Expected:   dayElem,
+			Reason:     ReasonOutOfRange,
+		}
+	}
+
+	// date builds the time in a location with daylight saving transitions,
+	// resolving gaps and overlaps by the configured policy.
+	date := func(loc *time.Location) (time.Time, error) {
+		t, err := o.dst.date(year, time.Month(month), day, hour, min, sec, nsec, loc)
+		if err != nil {
+			dstErr := err.(*DSTError)
+			dstErr.Layout, dstErr.Value = alayout, avalue
+			reason := ReasonSkippedTime
+			if dstErr.Overlap {
+				reason = ReasonAmbiguousTime
+			}
+			if hourAt == -1 {
+				// Midnight, as the layout has no hour.
+				hourAt, hourField = len(avalue)-len(value), "hour"
+			}
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: avalue[hourAt:], Message: ": " + dstErr.detail()},
+				Field:      hourField,
+				Offset:     hourAt,
+				Expected:   hourElem,
+				Reason:     reason,
+				Err:        dstErr,
+			}
+		}
+		return t, nil
 	}
 
 	if z != nil {
-		return time.Date(year, time.Month(month), day, hour, min, sec, nsec, z), nil
+		return date(z)
 	}
 
 	if zoneOffset != -1 {
-		// Create fake zone to record offset.
-		z = time.FixedZone(zoneName, zoneOffset)
-		// TODO: check UTC and local zone
-		t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, z)
-		return t, nil
+		t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC)
+		t = t.Add(-time.Duration(zoneOffset) * time.Second)
+		return o.offsetZone(t, zoneName, zoneOffset, local), nil
 	}
 
 	if zoneName != "" {
-		// Look for local zone with the given offset.
-		// If that zone was in effect at the given time, use it.
+		// Look for the zone the abbreviation stands for.
 		var err error
 		var offset int
-		zone, ok := shortIDs[zoneName]
-		if ok {
-			if zone.name != "" {
-				zoneName = zone.name
-			} else {
-				t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, local)
-				_, offset = t.Zone()
-				if offset != zone.offset {
-					return t.Add(time.Duration(offset-zone.offset) * time.Second), nil
-				}
+		zones := o.zoneResolver().ResolveZone(zoneName)
+		if len(zones) > 1 && o.ambiguousZones {
+			return time.Time{}, &ParseError{
+				ParseError: time.ParseError{Layout: alayout, Value: avalue, ValueElem: zoneName, Message: ": ambiguous time zone " + zoneName},
+				Field:      "zone",
+				Offset:     zoneAt,
+				Expected:   zoneElem,
+				Reason:     ReasonAmbiguousZone,
 			}
 		}
-		z, err = time.LoadLocation(zoneName)
+		var zone Zone
+		if len(zones) > 0 {
+			if zone = zones[0]; zone.Name == "" {
+				// A fixed offset; report the instant in the local zone.
+				z = time.FixedZone(zoneName, zone.Offset)
+				t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, z)
+				return t.In(local), nil
+			}
+			zoneName = zone.Name
+		}
+		z, err = o.locationLoader().LoadLocation(zoneName)
 		if err == nil {
-			t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, z)
-			return t, nil
+			if !zone.HasOffset {
+				return date(z)
+			}
+			// The abbreviation fixes the offset, such as the daylight
+			// saving one of the location even in winter.
+			t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.FixedZone(zoneName, zone.Offset))
+			return t.In(z), nil
 		}
 
 		// Otherwise, create fake zone with unknown offset.
@@ -968,7 +1881,25 @@ func parse(layout, value string, defaultLocation, local *time.Location) (time.Ti
 	}
 
 	// Otherwise, fall back to default.
-	return time.Date(year, time.Month(month), day, hour, min, sec, nsec, defaultLocation), nil
+	return date(defaultLocation)
+}
+
+// offsetZone returns t in a location with the given parsed offset.
+func (o *options) offsetZone(t time.Time, zoneName string, zoneOffset int, local *time.Location) time.Time {
+	if !o.fixedZone {
+		// Look for local zone with the given offset, and the given
+		// abbreviation if any. If that zone was in effect at the given
+		// time, use it.
+		if name, offset := t.In(local).Zone(); offset == zoneOffset && (zoneName == "" || name == zoneName) {
+			return t.In(local)
+		}
+		if zoneOffset == 0 && (zoneName == "" || zoneName == "UTC") {
+			return t.UTC()
+		}
+	}
+
+	// Otherwise create fake zone to record offset.
+	return t.In(time.FixedZone(zoneName, zoneOffset))
 }
 
 // parseTimeZone parses a time zone string and returns its length. Time zones
@@ -1078,6 +2009,58 @@ func parseNanoseconds(value string, nbytes int) (ns int, rangeErrString string,
 	return
 }
 
+// epochTime returns the Unix time of n units of 10^-digits seconds since
+// the epoch, plus the fraction of a unit given by the decimal digits frac,
+// which counts towards the epoch when neg is set.
+func epochTime(n int64, digits int, frac string, neg bool) (sec, nsec int64) {
+	perSec, unit := int64(1), int64(1e9)
+	for i := 0; i < digits; i++ {
+		perSec *= 10
+		unit /= 10
+	}
+	sec, nsec = n/perSec, n%perSec*unit
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	if frac != "" {
+		f, _ := atoi(frac)
+		scale := int64(1)
+		for range frac {
+			scale *= 10
+		}
+		if neg {
+			nsec -= int64(f) * unit / scale
+		} else {
+			nsec += int64(f) * unit / scale
+		}
+	}
+	return
+}
+
+// fractionDigits returns the number of leading digits of value taken by a
+// fractional second: up to nine, or all of them when they are rounded.
+func (o *options) fractionDigits(value string) int {
+	i := 0
+	for isDigit(value, i) && (i < 9 || o.fracRounding != 0) {
+		i++
+	}
+	return i
+}
+
+// parseFraction is like parseNanoseconds, but digits beyond nanoseconds
+// are rounded by the configured rounding mode, if any.
+func (o *options) parseFraction(value string, nbytes int) (ns int, rangeErrString string, err error) {
+	if nbytes <= 9 || o.fracRounding == 0 {
+		return parseNanoseconds(value, nbytes)
+	}
+	ns, rangeErrString, err = parseNanoseconds(value, 9)
+	if err == nil && rangeErrString == "" && o.fracRounding.roundUpDigits(ns%2 == 1, value[9:nbytes]) {
+		// A carry into the seconds is normalized by time.Date.
+		ns++
+	}
+	return
+}
+
 var errLeadingInt = errors.New("time: bad [0-9]*") // never printed
 
 // leadingInt consumes the leading [0-9]* from s.
@@ -1,6 +1,7 @@
 package jodatime_test
 
 import (
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -40,6 +41,10 @@ var formatTests = []FormatTest{
 	// Joda time quotes.
 	{"escape for text", "'YYZbca'Y", "YYZbca2009"},
 	{"single quote", "''YYYY", "'2009"},
+	{"quoted T", "yyyy-MM-dd'T'HH:mm", "2009-02-04T21:00"},
+	{"quote in quoted text", "h 'o''clock' a", "9 o'clock PM"},
+	{"two single quotes", "''''", "''"},
+	{"quoted pattern letters", "'at' HH 'on' EEE", "at 21 on Wed"},
 }
 
 func TestFormat(t *testing.T) {
@@ -157,3 +162,265 @@ func BenchmarkTimeParse(b *testing.B) {
 		time.Parse(s, RFC3339)
 	}
 }
+
+type DSTTest struct {
+	name   string
+	policy DSTPolicy
+	value  string
+	result string // RFC3339 in Local, empty for a *DSTError
+}
+
+var dstTests = []DSTTest{
+	{"gap compatible", DSTCompatible, "2024-03-10 02:30", "2024-03-10T01:30:00-08:00"},
+	{"gap earlier", DSTEarlier, "2024-03-10 02:30", "2024-03-10T03:30:00-07:00"},
+	{"gap later", DSTLater, "2024-03-10 02:30", "2024-03-10T01:30:00-08:00"},
+	{"gap shift forward", DSTShiftForward, "2024-03-10 02:30", "2024-03-10T03:00:00-07:00"},
+	{"gap reject", DSTReject, "2024-03-10 02:30", ""},
+	{"overlap compatible", DSTCompatible, "2024-11-03 01:30", "2024-11-03T01:30:00-07:00"},
+	{"overlap earlier", DSTEarlier, "2024-11-03 01:30", "2024-11-03T01:30:00-07:00"},
+	{"overlap later", DSTLater, "2024-11-03 01:30", "2024-11-03T01:30:00-08:00"},
+	{"overlap shift forward", DSTShiftForward, "2024-11-03 01:30", "2024-11-03T01:30:00-08:00"},
+	{"overlap reject", DSTReject, "2024-11-03 01:30", ""},
+	{"no transition", DSTReject, "2024-03-10 01:30", "2024-03-10T01:30:00-08:00"},
+}
+
+func TestParseDST(t *testing.T) {
+	for _, test := range dstTests {
+		time, err := ParseInLocation("YYYY-MM-dd HH:mm", test.value, local, WithDSTPolicy(test.policy))
+		if test.result == "" {
+			var e *DSTError
+			if !errors.As(err, &e) {
+				t.Errorf("%s expected *DSTError got %v", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+		} else if result := Format(time, RFC3339); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+}
+
+func TestExpandedYear(t *testing.T) {
+	tests := []struct {
+		value  string
+		year   int
+		result string
+	}{
+		{"+012345-01-01", 12345, "+012345-01-01"},
+		{"-0044-03-15", -44, "-000044-03-15"},
+		{"2009-02-04", 2009, "+002009-02-04"},
+		{"12345-01-01", 12345, "+012345-01-01"},
+		// As in Joda, a year not followed by another number may have
+		// fewer digits than letters.
+		{"9-02-04", 9, "+000009-02-04"},
+		{"309-02-04", 309, "+000309-02-04"},
+	}
+	for _, test := range tests {
+		tm, err := Parse("yyyy-MM-dd", test.value)
+		if err != nil {
+			t.Errorf("%s error: %v", test.value, err)
+			continue
+		}
+		if tm.Year() != test.year {
+			t.Errorf("%s expected year %d got %d", test.value, test.year, tm.Year())
+		}
+		if result := Format(tm, "yyyy-MM-dd", WithExpandedYear(6)); result != test.result {
+			t.Errorf("%s expected %q got %q", test.value, test.result, result)
+		}
+	}
+
+	// A year followed by another number keeps four digits.
+	tm, err := Parse("yyyyMMdd", "20090204")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := Format(tm, "yyyy-MM-dd"); result != "2009-02-04" {
+		t.Errorf("expected %q got %q", "2009-02-04", result)
+	}
+}
+
+var widthTests = []FormatTest{
+	{"day", "d ddd", "4 004"},
+	{"hour", "H HH HHH", "21 21 021"},
+	{"hour12", "h hhh", "9 009"},
+	{"minute", "m mmm", "0 000"},
+	{"second", "s sss", "57 057"},
+	{"month", "M MM", "2 02"},
+	{"year", "y yyyyyy", "2009 002009"},
+}
+
+func TestFormatWidth(t *testing.T) {
+	time := time.Unix(0, 1233810057012345600)
+	for _, test := range widthTests {
+		result := Format(time, test.format)
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+		parsed, err := ParseInLocation(test.format, result, time.Location())
+		if err != nil {
+			t.Errorf("%s parse error: %v", test.name, err)
+		} else if again := Format(parsed, test.format); again != result {
+			t.Errorf("%s round trip expected %q got %q", test.name, result, again)
+		}
+	}
+
+	// H is not padded, as in Joda; this package used to print "09".
+	morning := time.Add((12*60 + 5) * 60 * 1e9) // 09:05:57 the next day
+	if result := Format(morning, "H:mm HH:mm"); result != "9:05 09:05" {
+		t.Errorf("expected %q got %q", "9:05 09:05", result)
+	}
+}
+
+var adjacentTests = []struct {
+	format string
+	value  string
+	result string
+}{
+	{"yyyyMMddHHmmssSSS", "20090204210057012", "2009-02-04 21:00:57.012"},
+	{"yyMMdd", "090204", "2009-02-04 00:00:00.000"},
+	{"HHmm", "2100", "0000-01-01 21:00:00.000"},
+	{"Hmm", "900", "0000-01-01 09:00:00.000"},
+	{"Hmm", "2100", "0000-01-01 21:00:00.000"},
+	{"yyyyMd", "200924", "2009-02-04 00:00:00.000"},
+	{"yyyyyyMMdd", "+0020090204", "2009-02-04 00:00:00.000"},
+	{"MMddyyyy", "02042009", "2009-02-04 00:00:00.000"},
+}
+
+func TestParseAdjacent(t *testing.T) {
+	for _, test := range adjacentTests {
+		tm, err := Parse(test.format, test.value)
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.format, test.value, err)
+			continue
+		}
+		if result := Format(tm, "yyyy-MM-dd HH:mm:ss.SSS"); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.format, test.value, test.result, result)
+		}
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		n      int
+		result string
+	}{
+		{"yyyy-MM-dd HH:mm:ss,SSS", "2024-05-01 10:00:00,123 INFO started", 23, "2024-05-01 10:00:00.123"},
+		{"yyyy-MM-dd HH:mm:ss", "2024-05-01 10:00:00.5 INFO", 21, "2024-05-01 10:00:00.500"},
+		{"yyyy-MM-dd' '", "2024-05-01    x", 14, "2024-05-01 00:00:00.000"},
+		{"yyyy-MM-dd", "2024-05-01", 10, "2024-05-01 00:00:00.000"},
+	}
+	for _, test := range tests {
+		tm, n, err := ParsePrefix(test.format, test.value)
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.format, test.value, err)
+			continue
+		}
+		if n != test.n {
+			t.Errorf("%s %q expected %d bytes got %d", test.format, test.value, test.n, n)
+		}
+		if result := Format(tm, "yyyy-MM-dd HH:mm:ss.SSS"); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.format, test.value, test.result, result)
+		}
+	}
+
+	if _, n, err := ParsePrefix("yyyy-MM-dd", "2024-13-01 INFO"); err == nil || n != 0 {
+		t.Errorf("expected an error and 0 bytes got %d, %v", n, err)
+	}
+	f := NewFormatter("HH:mm:ss")
+	if _, n, err := f.ParsePrefix("10:00:00 up"); err != nil || n != 8 {
+		t.Errorf("Formatter expected 8 bytes got %d, %v", n, err)
+	}
+}
+
+func TestParseZeroOffsetZ(t *testing.T) {
+	for _, layout := range []string{"yyyy-MM-dd'T'HH:mm:ssZ", "yyyy-MM-dd'T'HH:mm:ssZZ"} {
+		tm, err := Parse(layout, "2009-02-04T21:00:57Z")
+		if err != nil {
+			t.Errorf("%s error: %v", layout, err)
+		} else if _, offset := tm.Zone(); offset != 0 || tm.Hour() != 21 {
+			t.Errorf("%s expected 21:00:57 UTC got %v", layout, tm)
+		}
+		if result := Format(tm, layout); result == "2009-02-04T21:00:57Z" {
+			t.Errorf("%s expected a numeric offset got %q", layout, result)
+		}
+	}
+}
+
+func TestParseColonOffset(t *testing.T) {
+	// Z parses an offset without a colon; ZZ is the one with.
+	if _, err := Parse("yyyy-MM-dd'T'HH:mm:ssZ", "2009-02-04T21:00:57-08:00"); err == nil {
+		t.Error("Z expected error for -08:00")
+	}
+	if _, err := Parse("yyyy-MM-dd'T'HH:mm:ssZZ", "2009-02-04T21:00:57-08:00"); err != nil {
+		t.Errorf("ZZ error: %v", err)
+	}
+}
+
+func TestParseQuotes(t *testing.T) {
+	for layout, value := range map[string]string{
+		"yyyy-MM-dd'T'HH:mm:ss":           "2009-02-04T21:00:57",
+		"yyyy-MM-dd 'at' HH:mm:ss":        "2009-02-04 at 21:00:57",
+		"yyyy-MM-dd h:mm:ss 'o''clock' a": "2009-02-04 9:00:57 o'clock PM",
+		"''yyyy-MM-dd HH:mm:ss''":         "'2009-02-04 21:00:57'",
+	} {
+		tm, err := Parse(layout, value)
+		if err != nil {
+			t.Errorf("%s %q error: %v", layout, value, err)
+		} else if result := Format(tm, "yyyy-MM-dd HH:mm:ss"); result != "2009-02-04 21:00:57" {
+			t.Errorf("%s %q expected %q got %q", layout, value, "2009-02-04 21:00:57", result)
+		}
+	}
+}
+
+var weekTests = []FormatTest{
+	{"day of year", "yyyy-DDD", "2009-035"},
+	{"day of year short", "D", "35"},
+	{"week date", "xxxx-'W'ww-e", "2009-W06-3"},
+	{"basic week date", "xxxx'W'wwe", "2009W063"},
+	{"two-digit week year", "xx", "09"},
+	{"day of week", "e", "3"},
+	{"week", "w", "6"},
+}
+
+func TestWeekDate(t *testing.T) {
+	time := time.Unix(0, 1233810057012345600)
+	for _, test := range weekTests {
+		result := Format(time, test.format)
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+		if test.format == "D" || test.format == "xx" || test.format == "e" || test.format == "w" {
+			continue
+		}
+		parsed, err := ParseInLocation(test.format, result, time.Location())
+		if err != nil {
+			t.Errorf("%s parse error: %v", test.name, err)
+		} else if parsed.YearDay() != 35 || parsed.Year() != 2009 {
+			t.Errorf("%s expected 2009-02-04 got %v", test.name, parsed)
+		}
+	}
+
+	// The week-based year differs from the year around January 1st.
+	for value, date := range map[string]string{
+		"2009-W01-1": "2008-12-29",
+		"2020-W53-7": "2021-01-03",
+		"2024-W01-1": "2024-01-01",
+	} {
+		parsed, err := Parse("xxxx-'W'ww-e", value)
+		if err != nil {
+			t.Errorf("%s error: %v", value, err)
+		} else if result := Format(parsed, "yyyy-MM-dd"); result != date {
+			t.Errorf("%s expected %q got %q", value, date, result)
+		}
+		if result := Format(parsed, "xxxx-'W'ww-e"); result != value {
+			t.Errorf("%s formatted as %q", value, result)
+		}
+	}
+	if _, err := Parse("yyyy-DDD", "2009-366"); err == nil {
+		t.Errorf("day 366 of 2009 expected error")
+	}
+}
@@ -0,0 +1,57 @@
+package jodatime
+
+import "time"
+
+// A Formatter is a layout bound to a set of options, so that they do not
+// have to be passed on every call. It is safe for concurrent use.
+type Formatter struct {
+	layout string
+	opts   options
+}
+
+// NewFormatter returns a Formatter for layout configured by opts.
+func NewFormatter(layout string, opts ...Option) *Formatter {
+	return &Formatter{layout: layout, opts: newOptions(opts)}
+}
+
+// Layout returns the layout of f.
+func (f *Formatter) Layout() string {
+	return f.layout
+}
+
+// With returns a copy of f with opts applied on top of its options.
+func (f *Formatter) With(opts ...Option) *Formatter {
+	g := *f
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+	return &g
+}
+
+// Parse is like the package function Parse using the layout and options of f.
+func (f *Formatter) Parse(value string) (time.Time, error) {
+	return parse(f.layout, value, time.UTC, time.Local, &f.opts, nil)
+}
+
+// ParseInLocation is like the package function ParseInLocation using the
+// layout and options of f.
+func (f *Formatter) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	return parse(f.layout, value, loc, loc, &f.opts, nil)
+}
+
+// ParsePrefix is like the package function ParsePrefix using the layout
+// and options of f.
+func (f *Formatter) ParsePrefix(value string) (t time.Time, n int, err error) {
+	return parsePrefix(f.layout, value, time.UTC, time.Local, &f.opts)
+}
+
+// Format is like the package function Format using the layout and options of f.
+func (f *Formatter) Format(t time.Time) string {
+	return format(t, f.layout, &f.opts)
+}
+
+// AppendFormat is like the package function AppendFormat using the layout
+// and options of f.
+func (f *Formatter) AppendFormat(b []byte, t time.Time) []byte {
+	return appendFormat(t, b, f.layout, &f.opts)
+}
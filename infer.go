@@ -0,0 +1,404 @@
+package jodatime
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Fields of a layout, to keep each in a candidate layout once.
+const (
+	fieldYear = 1 << iota
+	fieldMonth
+	fieldDay
+	fieldYearDay
+	fieldWeekDay
+	fieldHour
+	fieldMinute
+	fieldSecond
+	fieldFraction
+	fieldAMPM
+	fieldZone
+	fieldEpoch
+)
+
+// maxInferred bounds the number of candidate layouts InferLayout tries.
+const maxInferred = 4096
+
+// An inferAlt is a way to write part of a sample in a layout.
+type inferAlt struct {
+	layout string
+	fields int
+}
+
+// InferLayout returns the Joda layouts that parse all samples, best first.
+// It reads the first sample as dates and times with their separators,
+// month and weekday names, 12- or 24-hour clocks, fractional seconds and
+// zones, and tries the ways to write it, such as day before or after
+// month. A layout is only returned if every sample parses, and formats
+// back into text that parses to the same time; those that format every
+// sample back exactly come first. Only the first sample shapes the
+// candidates; the others can only rule them out, so samples whose fields
+// differ in form from the first, like "9:05" after "2:30 PM", find no
+// layout.
+func InferLayout(samples []string) ([]string, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("jodatime: no samples to infer a layout from")
+	}
+	candidates := expandAlts(inferSlots(samples[0]))
+	type ranked struct {
+		layout string
+		exact  int
+	}
+	var layouts []ranked
+	seen := make(map[string]bool)
+	for _, layout := range candidates {
+		if seen[layout] {
+			continue
+		}
+		seen[layout] = true
+		if exact, ok := verifyLayout(layout, samples); ok {
+			layouts = append(layouts, ranked{layout, exact})
+		}
+	}
+	if len(layouts) == 0 {
+		return nil, errors.New("jodatime: no layout parses all samples")
+	}
+	sort.SliceStable(layouts, func(i, j int) bool { return layouts[i].exact > layouts[j].exact })
+	result := make([]string, len(layouts))
+	for i, l := range layouts {
+		result[i] = l.layout
+	}
+	return result, nil
+}
+
+// verifyLayout reports whether all samples parse under layout and format
+// back into text that parses to the same time, and how many format back
+// into the sample itself.
+func verifyLayout(layout string, samples []string) (exact int, ok bool) {
+	for _, s := range samples {
+		t, err := Parse(layout, s)
+		if err != nil {
+			return 0, false
+		}
+		f := Format(t, layout)
+		if f == s {
+			exact++
+			continue
+		}
+		if u, err := Parse(layout, f); err != nil || !u.Equal(t) {
+			return 0, false
+		}
+	}
+	return exact, true
+}
+
+// expandAlts returns the layouts made of one alternative of each slot,
+// with some field and none twice.
+func expandAlts(slots [][]inferAlt) []string {
+	var layouts []string
+	var walk func(i int, layout string, fields int)
+	walk = func(i int, layout string, fields int) {
+		if len(layouts) >= maxInferred {
+			return
+		}
+		if i == len(slots) {
+			if fields != 0 {
+				layouts = append(layouts, layout)
+			}
+			return
+		}
+		for _, alt := range slots[i] {
+			if alt.fields&fields == 0 {
+				walk(i+1, layout+alt.layout, fields|alt.fields)
+			}
+		}
+	}
+	walk(0, "", 0)
+	return layouts
+}
+
+// splitSample splits s into runs of digits, runs of letters, runs of
+// spaces and single other bytes.
+func splitSample(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		j := i + 1
+		switch c := s[i]; {
+		case isDigit(s, i):
+			for isDigit(s, j) {
+				j++
+			}
+		case isLetter(c):
+			for j < len(s) && isLetter(s[j]) {
+				j++
+			}
+		case c == ' ':
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+		}
+		tokens = append(tokens, s[i:j])
+		i = j
+	}
+	return tokens
+}
+
+// inferSlots returns the ways to write each part of sample in a layout.
+func inferSlots(sample string) [][]inferAlt {
+	tokens := splitSample(sample)
+	isNum := func(k int) bool { return k < len(tokens) && isDigit(tokens[k], 0) }
+	is := func(k int, s string) bool { return k < len(tokens) && tokens[k] == s }
+	twelveHour := false
+	for _, t := range tokens {
+		if isAMPM(t) {
+			twelveHour = true
+		}
+	}
+
+	var slots [][]inferAlt
+	literal := func(s string) {
+		slots = append(slots, []inferAlt{{string(appendJodaLiteral(nil, s)), 0}})
+	}
+	timeSeen := false
+	for k := 0; k < len(tokens); k++ {
+		tok := tokens[k]
+		switch {
+		case isNum(k) && is(k+1, ":") && isNum(k+2):
+			// A time of day, as in 21:00:57.012.
+			timeSeen = true
+			if twelveHour {
+				slots = append(slots, widthAlts(tok, "h", fieldHour))
+			} else {
+				slots = append(slots, widthAlts(tok, "H", fieldHour))
+			}
+			literal(":")
+			slots = append(slots, widthAlts(tokens[k+2], "m", fieldMinute))
+			k += 2
+			if is(k+1, ":") && isNum(k+2) {
+				literal(":")
+				slots = append(slots, widthAlts(tokens[k+2], "s", fieldSecond))
+				k += 2
+				if (is(k+1, ".") || is(k+1, ",")) && isNum(k+2) {
+					literal(tokens[k+1])
+					slots = append(slots, fractionAlts(len(tokens[k+2])))
+					k += 2
+				}
+			}
+		case isNum(k) && (is(k+1, "-") || is(k+1, "/") || is(k+1, ".")) && isNum(k+2):
+			// A numeric date, as in 2009-02-04 or 04/02/09.
+			parts := []string{tok, tokens[k+2]}
+			k += 2
+			if is(k+1, tokens[k-1]) && isNum(k+2) {
+				parts = append(parts, tokens[k+2])
+				k += 2
+			}
+			slots = append(slots, dateAlts(parts, tokens[k-1]))
+		case isNum(k):
+			next := k + 1
+			if is(next, " ") {
+				next++
+			}
+			hour := next < len(tokens) && isAMPM(tokens[next])
+			if alts := numberAlts(tok, hour, k > 0 && tokens[k-1] == "T"); alts != nil {
+				slots = append(slots, alts)
+			} else {
+				literal(tok)
+			}
+		case (tok == "+" || tok == "-") && timeSeen && isNum(k+1):
+			// A zone offset, as in -08:00 or +0800.
+			if is(k+2, ":") && isNum(k+3) {
+				slots = append(slots, []inferAlt{{"ZZ", fieldZone}, {"Z", fieldZone}})
+				k += 3
+			} else {
+				slots = append(slots, []inferAlt{{"Z", fieldZone}})
+				k++
+			}
+		case isLetter(tok[0]):
+			if alts := nameAlts(tok, timeSeen); alts != nil {
+				slots = append(slots, alts)
+			} else {
+				literal(tok)
+			}
+		default:
+			literal(tok)
+		}
+	}
+	return slots
+}
+
+// widthAlts returns the letter c for a number written as digits, twice
+// first when it has two digits.
+func widthAlts(digits, c string, field int) []inferAlt {
+	if len(digits) == 2 {
+		return []inferAlt{{c + c, field}, {c, field}}
+	}
+	return []inferAlt{{c, field}}
+}
+
+// fractionAlts returns the ways to write n fractional digits: exactly, or
+// with up to nine digits for samples of varying precision.
+func fractionAlts(n int) []inferAlt {
+	alts := []inferAlt{{strings.Repeat("S", n), fieldFraction}}
+	if n < 9 {
+		alts = append(alts, inferAlt{"SSSSSSSSS", fieldFraction})
+	}
+	return alts
+}
+
+// dateAlts returns the ways to write the numeric date parts separated by
+// sep, in the orders most used with sep first.
+func dateAlts(parts []string, sep string) []inferAlt {
+	last := len(parts) - 1
+	var orders []string
+	switch {
+	case len(parts[0]) == 4:
+		orders = []string{"yMd"}
+	case len(parts[last]) == 4 && sep == "/":
+		orders = []string{"Mdy", "dMy"}
+	case len(parts[last]) == 4:
+		orders = []string{"dMy", "Mdy"}
+	case sep == "/":
+		orders = []string{"Mdy", "dMy", "yMd"}
+	case sep == "-":
+		orders = []string{"yMd", "dMy", "Mdy"}
+	default:
+		orders = []string{"dMy", "Mdy", "yMd"}
+	}
+	var alts []inferAlt
+	for _, order := range orders {
+		if len(parts) == 2 {
+			// Month and year, or day and month.
+			switch {
+			case len(parts[0]) == 4:
+				order = "yM"
+			case len(parts[1]) == 4:
+				order = "My"
+			default:
+				order = strings.Replace(order, "y", "", 1)
+			}
+		}
+		layouts := []string{""}
+		fields := 0
+		for i, part := range parts {
+			var field int
+			var ws []inferAlt
+			switch c := order[i : i+1]; c {
+			case "y":
+				field = fieldYear
+				if len(part) == 2 {
+					ws = []inferAlt{{"yy", field}}
+				} else {
+					ws = []inferAlt{{"yyyy", field}}
+				}
+			case "M":
+				field = fieldMonth
+				ws = widthAlts(part, c, field)
+			default:
+				field = fieldDay
+				ws = widthAlts(part, c, field)
+			}
+			fields |= field
+			var next []string
+			for _, l := range layouts {
+				if i > 0 {
+					l += string(appendJodaLiteral(nil, sep))
+				}
+				for _, w := range ws {
+					next = append(next, l+w.layout)
+				}
+			}
+			layouts = next
+		}
+		for _, l := range layouts {
+			alts = append(alts, inferAlt{l, fields})
+		}
+	}
+	return alts
+}
+
+// numberAlts returns the ways to write a number that stands alone: a
+// compact date or time, an epoch, or a single field. An hour is followed by
+// AM or PM, a time by T. It returns nil for a number taken literally, such
+// as one of five digits.
+func numberAlts(digits string, hour, afterT bool) []inferAlt {
+	date, clock := fieldYear|fieldMonth|fieldDay, fieldHour|fieldMinute|fieldSecond
+	switch len(digits) {
+	case 1, 2:
+		if hour {
+			return widthAlts(digits, "h", fieldHour)
+		}
+		alts := widthAlts(digits, "d", fieldDay)
+		if len(digits) == 2 {
+			alts = append(alts, inferAlt{"yy", fieldYear})
+		}
+		return alts
+	case 3:
+		return []inferAlt{{"DDD", fieldYearDay}}
+	case 4:
+		if afterT {
+			return []inferAlt{{"HHmm", fieldHour | fieldMinute}, {"yyyy", fieldYear}}
+		}
+		return []inferAlt{{"yyyy", fieldYear}, {"HHmm", fieldHour | fieldMinute}}
+	case 6:
+		if afterT {
+			return []inferAlt{{"HHmmss", clock}, {"yyMMdd", date}}
+		}
+		return []inferAlt{{"yyMMdd", date}, {"HHmmss", clock}}
+	case 8:
+		return []inferAlt{{"yyyyMMdd", date}}
+	case 10:
+		return []inferAlt{{"X", fieldEpoch}, {"yyyyMMddHH", date | fieldHour}}
+	case 12:
+		return []inferAlt{{"yyyyMMddHHmm", date | fieldHour | fieldMinute}}
+	case 13:
+		return []inferAlt{{"XXX", fieldEpoch}}
+	case 14:
+		return []inferAlt{{"yyyyMMddHHmmss", date | clock}}
+	case 16:
+		return []inferAlt{{"XXXXXX", fieldEpoch}}
+	case 17:
+		return []inferAlt{{"yyyyMMddHHmmssSSS", date | clock | fieldFraction}}
+	case 19:
+		return []inferAlt{{"XXXXXXXXX", fieldEpoch}}
+	}
+	return nil
+}
+
+// nameAlts returns the ways to write the letters s: a month or weekday
+// name, AM or PM, or after a time a zone. It returns nil for literal text.
+func nameAlts(s string, timeSeen bool) []inferAlt {
+	isName := func(names []string) bool {
+		for _, name := range names {
+			if len(s) == len(name) && match(s, name) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case isName(longMonthNames):
+		return []inferAlt{{"MMMM", fieldMonth}}
+	case isName(shortMonthNames):
+		return []inferAlt{{"MMM", fieldMonth}}
+	case isName(longDayNames):
+		return []inferAlt{{"EEEE", fieldWeekDay}}
+	case isName(shortDayNames):
+		return []inferAlt{{"EEE", fieldWeekDay}}
+	case isAMPM(s):
+		return []inferAlt{{"a", fieldAMPM}}
+	case !timeSeen:
+		return nil
+	case s == "Z":
+		return []inferAlt{{"ZZ", fieldZone}, {"Z", fieldZone}}
+	}
+	if n, ok := parseTimeZone(s); ok && n == len(s) {
+		return []inferAlt{{"ZZZ", fieldZone}}
+	}
+	return nil
+}
+
+// isAMPM reports whether s is AM or PM, in any case.
+func isAMPM(s string) bool {
+	return strings.EqualFold(s, "AM") || strings.EqualFold(s, "PM")
+}
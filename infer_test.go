@@ -0,0 +1,75 @@
+package jodatime_test
+
+import (
+	"testing"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var inferTests = []struct {
+	samples []string
+	layout  string
+}{
+	{[]string{"2009-02-04 21:00:57"}, "yyyy-MM-dd HH:mm:ss"},
+	{[]string{"2009-02-04T21:00:57.012-08:00"}, "yyyy-MM-dd'T'HH:mm:ss.SSSZZ"},
+	{[]string{"2009-02-04T21:00:57.5Z", "2009-02-04T21:00:57.125Z"}, "yyyy-MM-dd'T'HH:mm:ss.SSSSSSSSSZZ"},
+	{[]string{"04/02/2009", "13/02/2009"}, "dd/MM/yyyy"},
+	{[]string{"02/04/2009", "02/13/2009"}, "MM/dd/yyyy"},
+	{[]string{"4.2.2009"}, "d.M.yyyy"},
+	{[]string{"Wed, 04 Feb 2009 21:00:57 +0800"}, "EEE, dd MMM yyyy HH:mm:ss Z"},
+	{[]string{"February 4, 2009 9:00 PM"}, "MMMM d, yyyy h:mm a"},
+	{[]string{"04 Feb 2009 21:00 UTC"}, "dd MMM yyyy HH:mm ZZZ"},
+	{[]string{"20090204210057"}, "yyyyMMddHHmmss"},
+	{[]string{"1233810057"}, "X"},
+	{[]string{"1233810057012"}, "XXX"},
+	{[]string{"9:05 pm"}, "h:mm a"},
+	{[]string{"id 12345 2009-02-04 21:00:57"}, "'id' 12345 yyyy-MM-dd HH:mm:ss"},
+}
+
+func TestInferLayout(t *testing.T) {
+	for _, test := range inferTests {
+		layouts, err := InferLayout(test.samples)
+		if err != nil {
+			t.Errorf("InferLayout(%q) error: %v", test.samples, err)
+			continue
+		}
+		if layouts[0] != test.layout {
+			t.Errorf("InferLayout(%q) = %q, want %q first", test.samples, layouts, test.layout)
+		}
+		for _, layout := range layouts {
+			for _, sample := range test.samples {
+				if _, err := Parse(layout, sample); err != nil {
+					t.Errorf("InferLayout(%q) returned %q, which does not parse %q: %v", test.samples, layout, sample, err)
+				}
+			}
+		}
+	}
+}
+
+func TestInferLayoutLowerCasePM(t *testing.T) {
+	layouts, err := InferLayout([]string{"9:05 pm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm, err := Parse(layouts[0], "9:05 pm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Hour() != 21 || tm.Minute() != 5 {
+		t.Errorf("%s expected 21:05 got %v", layouts[0], tm)
+	}
+}
+
+func TestInferLayoutErrors(t *testing.T) {
+	for _, samples := range [][]string{
+		nil,
+		{"hello"},
+		{"2009-02-04", "21:00:57"},
+		// Only the first sample shapes the candidates.
+		{"2:30 PM", "9:05"},
+	} {
+		if layouts, err := InferLayout(samples); err == nil {
+			t.Errorf("InferLayout(%q) = %q, want error", samples, layouts)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package jodatime
+
+import (
+	"strings"
+	"time"
+)
+
+// javaTimeStd returns the std value of j pattern letters c.
+func javaTimeStd(c byte, j int) int {
+	width := j << stdArgShift
+	switch c {
+	case 'G':
+		if j <= 3 {
+			return stdEra
+		}
+		return stdEra | width
+	case 'u', 'y':
+		era := 0
+		if c == 'y' {
+			era = stdYearOfEra
+		}
+		switch {
+		case j == 2:
+			return stdYear | width | era | stdBase2000
+		case j >= 4:
+			return stdLongYear | width | era | stdSignPad
+		}
+		return stdLongYear | width | era
+	case 'Y':
+		return stdWeekYear | width
+	case 'Q', 'q':
+		switch j {
+		case 1, 2:
+			return stdQuarter | width
+		case 3:
+			return stdQuarterText
+		case 4:
+			return stdQuarterText | width
+		}
+		return stdQuarter | 1<<stdArgShift
+	case 'M', 'L':
+		switch j {
+		case 1:
+			return stdNumMonth | width
+		case 2:
+			return stdZeroMonth | width
+		case 3:
+			return stdMonth
+		case 4:
+			return stdLongMonth
+		}
+		return stdMonth | 5<<stdArgShift
+	case 'w':
+		return stdWeek | width
+	case 'd':
+		if j == 1 {
+			return stdDay | width
+		}
+		return stdZeroDay | width
+	case 'D':
+		return stdYearDay | width
+	case 'E', 'e', 'c':
+		switch {
+		case j <= 2 && c != 'E':
+			return stdISOWeekDay | width
+		case j <= 3:
+			return stdWeekDay
+		case j == 4:
+			return stdLongWeekDay
+		}
+		return stdWeekDay | 5<<stdArgShift
+	case 'a':
+		return stdPM
+	case 'h':
+		if j == 1 {
+			return stdHour12 | width
+		}
+		return stdZeroHour12 | width
+	case 'K':
+		return stdHour11 | width
+	case 'k':
+		return stdHour24 | width
+	case 'H':
+		return stdHour | width
+	case 'm':
+		if j == 1 {
+			return stdMinute | width
+		}
+		return stdZeroMinute | width
+	case 's':
+		if j == 1 {
+			return stdSecond | width
+		}
+		return stdZeroSecond | width
+	case 'S':
+		return stdFracSecond0 | width
+	case 'A':
+		return stdMilliOfDay | width
+	case 'n':
+		return stdNanoSecond | width
+	case 'N':
+		return stdNanoOfDay | width
+	case 'V':
+		if j == 2 {
+			return stdZoneID
+		}
+		return stdUnsupported
+	case 'z':
+		return stdTZ
+	case 'O':
+		if j == 4 {
+			return stdGMTOffset | width
+		}
+		return stdGMTOffset
+	case 'X':
+		switch j {
+		case 1:
+			return stdISO8601ShortTZ | stdOptional
+		case 2:
+			return stdISO8601TZ
+		case 3:
+			return stdISO8601ColonTZ
+		case 4:
+			return stdISO8601SecondsTZ | stdOptional
+		}
+		return stdISO8601ColonSecondsTZ | stdOptional
+	case 'x':
+		switch j {
+		case 1:
+			return stdNumShortTZ | stdOptional
+		case 2:
+			return stdNumTZ
+		case 3:
+			return stdNumColonTZ
+		case 4:
+			return stdNumSecondsTz | stdOptional
+		}
+		return stdNumColonSecondsTZ | stdOptional
+	case 'Z':
+		switch j {
+		case 1, 2, 3:
+			return stdNumTZ
+		case 4:
+			return stdGMTOffset | width
+		}
+		return stdISO8601ColonSecondsTZ | stdOptional
+	}
+	return 0
+}
+
+// parseGMTOffset parses a localized offset such as "GMT", "GMT-8" or
+// "GMT-08:00" and returns it in seconds. The long form has two-digit hours
+// and minutes.
+func parseGMTOffset(value string, long bool) (int, string, error) {
+	if len(value) < 3 || value[:3] != "GMT" {
+		return 0, value, errBad
+	}
+	value = value[3:]
+	if value == "" || value[0] != '+' && value[0] != '-' {
+		return 0, value, nil
+	}
+	neg := value[0] == '-'
+	hr, value, err := getnum(value[1:], long)
+	if err != nil {
+		return 0, value, err
+	}
+	var mm, ss int
+	if len(value) >= 3 && value[0] == ':' {
+		if mm, value, err = getnum(value[1:], true); err != nil {
+			return 0, value, err
+		}
+		if len(value) >= 3 && value[0] == ':' {
+			if ss, value, err = getnum(value[1:], true); err != nil {
+				return 0, value, err
+			}
+		}
+	} else if long {
+		return 0, value, errBad
+	}
+	if hr > 18 || mm >= 60 || ss >= 60 {
+		return 0, value, errBad
+	}
+	offset := (hr*60+mm)*60 + ss
+	if neg {
+		offset = -offset
+	}
+	return offset, value, nil
+}
+
+// maxZoneIDLength bounds the length of a zone ID read from a value; the
+// longest IANA name, "America/Argentina/ComodRivadavia", is 32 bytes.
+const maxZoneIDLength = 64
+
+// parseZoneID parses a time zone ID such as "America/Los_Angeles", "UTC",
+// "Z" or "+08:00", as java.time's ZoneId.of does. A region ID is only
+// loaded if it is shaped like an IANA name, so that the value cannot reach
+// other files.
+func (o *options) parseZoneID(value string) (*time.Location, string, error) {
+	if value == "" {
+		return nil, value, errBad
+	}
+	switch value[0] {
+	case 'Z':
+		return time.UTC, value[1:], nil
+	case '+', '-':
+		hr, rest, err := getnum(value[1:], true)
+		if err != nil || len(rest) < 3 || rest[0] != ':' {
+			return nil, value, errBad
+		}
+		mm, rest, err := getnum(rest[1:], true)
+		if err != nil || hr > 18 || mm >= 60 {
+			return nil, value, errBad
+		}
+		offset := (hr*60 + mm) * 60
+		if value[0] == '-' {
+			offset = -offset
+		}
+		return time.FixedZone("", offset), rest, nil
+	}
+	n := 0
+	for ; n < len(value); n++ {
+		c := value[n]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+			continue
+		}
+		if n == 0 || !('0' <= c && c <= '9' || c == '~' || c == '/' || c == '.' || c == '_' || c == '+' || c == '-') {
+			break
+		}
+	}
+	if n < 2 || !isZoneID(value[:n]) {
+		return nil, value, errBad
+	}
+	loc, err := o.locationLoader().LoadLocation(value[:n])
+	if err != nil {
+		return nil, value, errBad
+	}
+	return loc, value[n:], nil
+}
+
+// isZoneID reports whether id is shaped like an IANA zone name: not too
+// long, and made of names separated by single slashes, none of them "." or
+// "..".
+func isZoneID(id string) bool {
+	if len(id) > maxZoneIDLength {
+		return false
+	}
+	for _, name := range strings.Split(id, "/") {
+		if name == "" || name == "." || name == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package jodatime_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var javaTimeTests = []FormatTest{
+	{"ISO local date time", "uuuu-MM-dd'T'HH:mm:ss.SSS", "2009-02-04T21:00:57.012"},
+	{"year of era", "G yyyy", "AD 2009"},
+	{"two-digit year", "yy", "09"},
+	{"week date", "YYYY-'W'ww-e", "2009-W06-3"},
+	{"quarter", "Q QQ QQQ QQQQ", "1 01 Q1 1st quarter"},
+	{"narrow text", "MMMMM EEEEE", "F W"},
+	{"day of week", "EEE EEEE", "Wed Wednesday"},
+	{"hours", "H K k h a", "21 9 21 9 PM"},
+	{"milli of day", "A", "75657012"},
+	{"nano of second", "n", "12345600"},
+	{"nano of day", "N", "75657012345600"},
+	{"offset X", "X XX XXX", "-08 -0800 -08:00"},
+	{"offset x", "x xx xxx xxxxx", "-08 -0800 -08:00 -08:00"},
+	{"offset Z", "Z ZZZZ ZZZZZ", "-0800 GMT-08:00 -08:00"},
+	{"localized offset", "O", "GMT-8"},
+	{"zone id", "VV", "America/Los_Angeles"},
+	{"literal", "'o''clock' h", "o'clock 9"},
+}
+
+func TestJavaTimeFormat(t *testing.T) {
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	for _, test := range javaTimeTests {
+		result := Format(tm, test.format, WithDialect(JavaTime))
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	utc := tm.UTC()
+	for layout, result := range map[string]string{
+		"X XXX":  "Z Z",
+		"x xxx":  "+00 +00:00",
+		"O ZZZZ": "GMT GMT",
+		"ZZZZZ":  "Z",
+		"G yyyy": "AD 2009",
+		"u VV":   "2009 UTC",
+		"uuuuuu": "002009",
+		"yyyyyy": "002009",
+	} {
+		if got := Format(utc, layout, WithDialect(JavaTime)); got != result {
+			t.Errorf("%s expected %q got %q", layout, result, got)
+		}
+	}
+
+	bc := time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if got := Format(bc, "G yyyy uuuu", WithDialect(JavaTime)); got != "BC 0044 -0043" {
+		t.Errorf("expected %q got %q", "BC 0044 -0043", got)
+	}
+	big := time.Date(12345, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got := Format(big, "uuuu", WithDialect(JavaTime)); got != "+12345" {
+		t.Errorf("expected %q got %q", "+12345", got)
+	}
+	half := time.Date(2009, time.February, 4, 21, 0, 0, 0, time.FixedZone("", 5*3600+30*60))
+	if got := Format(half, "X O VV", WithDialect(JavaTime)); got != "+0530 GMT+5:30 +05:30" {
+		t.Errorf("expected %q got %q", "+0530 GMT+5:30 +05:30", got)
+	}
+}
+
+func TestJavaTimeParse(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  string
+		result string
+	}{
+		{"uuuu-MM-dd'T'HH:mm:ss.SSSXXX", "2009-02-04T21:00:57.012-08:00", "2009-02-05T05:00:57.012Z"},
+		{"uuuu-MM-dd'T'HH:mm:ssX", "2009-02-04T21:00:57Z", "2009-02-04T21:00:57.000Z"},
+		{"uuuu-MM-dd'T'HH:mm:ssX", "2009-02-04T21:00:57+0530", "2009-02-04T15:30:57.000Z"},
+		{"uuuu-MM-dd'T'HH:mm:ssX", "2009-02-04T21:00:57+05", "2009-02-04T16:00:57.000Z"},
+		{"uuuu-MM-dd HH:mm VV", "2009-07-04 21:00 America/New_York", "2009-07-05T01:00:00.000Z"},
+		{"uuuu-MM-dd HH:mm VV", "2009-07-04 21:00 +02:00", "2009-07-04T19:00:00.000Z"},
+		{"uuuu-MM-dd HH:mm O", "2009-07-04 21:00 GMT+2", "2009-07-04T19:00:00.000Z"},
+		{"uuuu-MM-dd HH:mm ZZZZ", "2009-07-04 21:00 GMT-08:00", "2009-07-05T05:00:00.000Z"},
+		{"yyMMdd", "690204", "2069-02-04T00:00:00.000Z"},
+		{"G yyyy-MM-dd", "BC 0044-03-15", "-0043-03-15T00:00:00.000Z"},
+		{"uuuu-MM-dd K a", "2009-02-04 9 PM", "2009-02-04T21:00:00.000Z"},
+		{"uuuu-MM-dd k", "2009-02-04 24", "2009-02-04T00:00:00.000Z"},
+		{"uuuu-MM-dd A", "2009-02-04 75657012", "2009-02-04T21:00:57.012Z"},
+		{"uuuu-MM-dd N", "2009-02-04 75657012345600", "2009-02-04T21:00:57.012Z"},
+		{"uuuu-MM-dd HH:mm:ss.n", "2009-02-04 21:00:57.12345600", "2009-02-04T21:00:57.012Z"},
+		{"YYYY-'W'ww-e", "2009-W06-3", "2009-02-04T00:00:00.000Z"},
+		{"uuuu-MM-dd QQQ", "2009-02-04 Q1", "2009-02-04T00:00:00.000Z"},
+	}
+	for _, test := range tests {
+		tm, err := Parse(test.layout, test.value, WithDialect(JavaTime))
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.layout, test.value, err)
+			continue
+		}
+		if result := Format(tm.UTC(), "uuuu-MM-dd'T'HH:mm:ss.SSSX", WithDialect(JavaTime)); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.layout, test.value, test.result, result)
+		}
+	}
+
+	for layout, value := range map[string]string{
+		"uuuu-MM-dd'T'HH:mm:ss.SSS": "2009-02-04T21:00:57.01",
+		"uuuu-MM-dd QQQ":            "2009-02-04 Q5",
+		"uuuu-MM-dd K":              "2009-02-04 12",
+		"uuuu-MM-dd O":              "2009-02-04 UTC",
+		"uuuu-MM-dd VV":             "2009-02-04 Nowhere/Special",
+	} {
+		if _, err := Parse(layout, value, WithDialect(JavaTime)); err == nil {
+			t.Errorf("%s %q expected error", layout, value)
+		}
+	}
+}
+
+func TestJavaTimeZoneID(t *testing.T) {
+	var loaded []string
+	loader := WithLocationLoader(LocationLoaderFunc(func(name string) (*time.Location, error) {
+		loaded = append(loaded, name)
+		return time.LoadLocation(name)
+	}))
+	for _, test := range []struct{ layout, value string }{
+		{"uuuu-MM-dd V", "2009-02-04 UTC"},
+		{"uuuu-MM-dd VVV", "2009-02-04 America/New_York"},
+		{"uuuu-MM-dd VV", "2009-02-04 America/../../../etc/passwd"},
+		{"uuuu-MM-dd VV", "2009-02-04 ./UTC"},
+		{"uuuu-MM-dd VV ", "2009-02-04 America//New_York "},
+		{"uuuu-MM-dd VV", "2009-02-04 " + strings.Repeat("Abcdefgh/", 8) + "UTC"},
+	} {
+		if _, err := Parse(test.layout, test.value, WithDialect(JavaTime), loader); err == nil {
+			t.Errorf("%s %q expected error", test.layout, test.value)
+		}
+	}
+	if loaded != nil {
+		t.Errorf("expected no location to be loaded got %q", loaded)
+	}
+
+	_, err := Parse("uuuu-MM-dd VVV", "2009-02-04 UTC", WithDialect(JavaTime))
+	var e *ParseError
+	if !errors.As(err, &e) || e.Reason != ReasonBadLayout || e.Expected != "VVV" {
+		t.Errorf("expected a bad layout error for VVV got %v", err)
+	}
+}
+
+func TestJavaTimeUnsupported(t *testing.T) {
+	// Letters without a meaning, or a count of them java.time does not
+	// have, are printed as they are and fail to parse.
+	tm := time.Date(2009, time.February, 4, 21, 0, 57, 0, time.UTC)
+	for _, test := range []struct{ layout, result string }{
+		{"uuuu VVV", "2009 VVV"},
+		{"uuuu b", "2009 b"},
+		{"uuuu 'b' b", "2009 b b"},
+	} {
+		if result := Format(tm, test.layout, WithDialect(JavaTime)); result != test.result {
+			t.Errorf("%s expected %q got %q", test.layout, test.result, result)
+		}
+		_, err := Parse(test.layout, test.result, WithDialect(JavaTime))
+		var e *ParseError
+		if !errors.As(err, &e) || e.Reason != ReasonBadLayout {
+			t.Errorf("%s expected a bad layout error got %v", test.layout, err)
+		}
+	}
+	_, err := Parse("uuuu-MM-dd b", "2009-02-04 b", WithDialect(SimpleDateFormat))
+	var e *ParseError
+	if !errors.As(err, &e) || e.Reason != ReasonBadLayout || e.Expected != "b" {
+		t.Errorf("SimpleDateFormat expected a bad layout error for b got %v", err)
+	}
+}
+
+func TestJodaEra(t *testing.T) {
+	tm, err := Parse("G YYYY-MM-dd kk:mm", "BC 0044-03-15 24:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Year() != -43 || tm.Hour() != 0 {
+		t.Errorf("expected year -43 hour 0 got %v", tm)
+	}
+	if result := Format(tm, "G YYYY K"); result != "BC 0044 0" {
+		t.Errorf("expected %q got %q", "BC 0044 0", result)
+	}
+}
@@ -147,46 +147,6 @@ func daysIn(m time.Month, year int) int {
 	return int(daysBefore[m] - daysBefore[m-1])
 }
 
-type zone struct {
-	name   string
-	offset int
-}
-
-// shortIDs is the zone id map
-// https://docs.oracle.com/javase/8/docs/api/java/time/ZoneId.html
-var shortIDs = map[string]zone{
-	"EST": zone{"", -18000},
-	"HST": zone{"", -36000},
-	"MST": zone{"", -25200},
-	"ACT": zone{"Australia/Darwin", 0},
-	"AET": zone{"Australia/Sydney", 0},
-	"AGT": zone{"America/Argentina/Buenos_Aires", 0},
-	"ART": zone{"Africa/Cairo", 0},
-	"AST": zone{"America/Anchorage", 0},
-	"BET": zone{"America/Sao_Paulo", 0},
-	"BST": zone{"Asia/Dhaka", 0},
-	"CAT": zone{"Africa/Harare", 0},
-	"CNT": zone{"America/St_Johns", 0},
-	"CST": zone{"America/Chicago", 0},
-	"CTT": zone{"Asia/Shanghai", 0},
-	"EAT": zone{"Africa/Addis_Ababa", 0},
-	"ECT": zone{"Europe/Paris", 0},
-	"IET": zone{"America/Indiana/Indianapolis", 0},
-	"IST": zone{"Asia/Kolkata", 0},
-	"JST": zone{"Asia/Tokyo", 0},
-	"MIT": zone{"Pacific/Apia", 0},
-	"NET": zone{"Asia/Yerevan", 0},
-	"NST": zone{"Pacific/Auckland", 0},
-	"PLT": zone{"Asia/Karachi", 0},
-	"PNT": zone{"America/Phoenix", 0},
-	"PRT": zone{"America/Puerto_Rico", 0},
-	"PST": zone{"America/Los_Angeles", 0},
-	"SST": zone{"Pacific/Guadalcanal", 0},
-	"VST": zone{"Asia/Ho_Chi_Minh", 0},
-	// extra zones
-	"PDT": zone{"", -25200},
-}
-
 func isLeap(year int) bool {
 	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
@@ -0,0 +1,83 @@
+package jodatime
+
+import (
+	"sync"
+	"time"
+)
+
+// A LocationLoader loads a location by its IANA name, as time.LoadLocation
+// does. It is used to look up the time zones found while parsing.
+type LocationLoader interface {
+	LoadLocation(name string) (*time.Location, error)
+}
+
+// LocationLoaderFunc adapts an ordinary function to a LocationLoader.
+type LocationLoaderFunc func(name string) (*time.Location, error)
+
+// LoadLocation calls f(name).
+func (f LocationLoaderFunc) LoadLocation(name string) (*time.Location, error) {
+	return f(name)
+}
+
+// maxLocationFailures bounds the failed loads a LocationCache remembers, as
+// names read from untrusted values could otherwise grow it without end.
+const maxLocationFailures = 1024
+
+// A LocationCache is a LocationLoader remembering the locations loaded by
+// another loader, and up to maxLocationFailures names it failed to load,
+// forgetting one of those when it needs room. It is safe for concurrent
+// use.
+type LocationCache struct {
+	loader   LocationLoader
+	m        sync.Map // name -> *time.Location
+	mu       sync.Mutex
+	failures map[string]error
+}
+
+// NewLocationCache returns a LocationCache in front of loader.
+// A nil loader uses time.LoadLocation.
+func NewLocationCache(loader LocationLoader) *LocationCache {
+	if loader == nil {
+		loader = LocationLoaderFunc(time.LoadLocation)
+	}
+	return &LocationCache{loader: loader}
+}
+
+// LoadLocation implements LocationLoader.
+func (c *LocationCache) LoadLocation(name string) (*time.Location, error) {
+	if v, ok := c.m.Load(name); ok {
+		return v.(*time.Location), nil
+	}
+	c.mu.Lock()
+	err := c.failures[name]
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := c.loader.LoadLocation(name)
+	if err != nil {
+		c.fail(name, err)
+		return nil, err
+	}
+	v, _ := c.m.LoadOrStore(name, loc)
+	return v.(*time.Location), nil
+}
+
+// fail remembers that loading name failed with err.
+func (c *LocationCache) fail(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures == nil {
+		c.failures = make(map[string]error)
+	}
+	if len(c.failures) >= maxLocationFailures {
+		for old := range c.failures {
+			delete(c.failures, old)
+			break
+		}
+	}
+	c.failures[name] = err
+}
+
+// defaultLocations is used when no LocationLoader is configured.
+var defaultLocations = NewLocationCache(nil)
@@ -0,0 +1,64 @@
+package jodatime_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestLocationCache(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	cache := NewLocationCache(LocationLoaderFunc(func(name string) (*time.Location, error) {
+		mu.Lock()
+		calls[name]++
+		mu.Unlock()
+		return time.LoadLocation(name)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := Parse(RFC1123, "Thu, 04 Feb 2010 21:00:57 PST", WithLocationLoader(cache))
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls["America/Los_Angeles"]; n < 1 || n > 8 {
+		t.Errorf("expected America/Los_Angeles to be loaded once per racing goroutine at most, got %d", n)
+	}
+
+	if _, err := cache.LoadLocation("No/Such_Zone"); err == nil {
+		t.Errorf("expected error for unknown location")
+	}
+	if _, err := cache.LoadLocation("No/Such_Zone"); err == nil || calls["No/Such_Zone"] != 1 {
+		t.Errorf("expected unknown location to be cached as a failure")
+	}
+
+	// Failures are forgotten when there are too many of them.
+	for i := 0; i < 2000; i++ {
+		cache.LoadLocation(fmt.Sprintf("No/Such_Zone_%d", i))
+	}
+	calls = map[string]int{}
+	for i := 0; i < 2000; i++ {
+		cache.LoadLocation(fmt.Sprintf("No/Such_Zone_%d", i))
+	}
+	if len(calls) == 0 || len(calls) == 2000 {
+		t.Errorf("expected some but not all failures to be cached, reloaded %d of 2000", len(calls))
+	}
+}
+
+func BenchmarkParseZoneName(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		Parse(RFC1123, "Thu, 04 Feb 2010 21:00:57 PST")
+	}
+}
@@ -0,0 +1,257 @@
+package jodatime
+
+import "strings"
+
+// momentLocalFormats are the localized formats of the Moment dialect, in
+// the English locale.
+var momentLocalFormats = map[string]string{
+	"LTS":  "h:mm:ss A",
+	"LT":   "h:mm A",
+	"L":    "MM/DD/YYYY",
+	"LL":   "MMMM D, YYYY",
+	"LLL":  "MMMM D, YYYY h:mm A",
+	"LLLL": "dddd, MMMM D, YYYY h:mm A",
+	"l":    "M/D/YYYY",
+	"ll":   "MMM D, YYYY",
+	"lll":  "MMM D, YYYY h:mm A",
+	"llll": "ddd, MMM D, YYYY h:mm A",
+}
+
+var minDayNames = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// ordinalSuffix returns the English suffix of the ordinal number n, such
+// as "st" for 1 and "th" for 11.
+func ordinalSuffix(n int) string {
+	if n%100/10 != 1 {
+		switch n % 10 {
+		case 1:
+			return "st"
+		case 2:
+			return "nd"
+		case 3:
+			return "rd"
+		}
+	}
+	return "th"
+}
+
+// expandMoment replaces the localized formats of layout, such as "LT", by
+// the tokens they stand for.
+func expandMoment(layout string) string {
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case '[':
+			if j := momentLiteralEnd(layout, i); j > 0 {
+				i = j
+			}
+			continue
+		case '\\':
+			i++
+			continue
+		case 'L', 'l':
+			n := 1
+			for n < 4 && i+n < len(layout) && layout[i+n] == c {
+				n++
+			}
+			if c == 'L' && strings.HasPrefix(layout[i:], "LTS") {
+				n = 3
+			} else if c == 'L' && strings.HasPrefix(layout[i:], "LT") {
+				n = 2
+			}
+			s := momentLocalFormats[layout[i:i+n]]
+			layout = layout[:i] + s + layout[i+n:]
+			i += len(s) - 1
+		}
+	}
+	return layout
+}
+
+// momentLiteralEnd returns the index of the bracket that closes the one at
+// layout[i], or -1 if there is none. Brackets do not nest.
+func momentLiteralEnd(layout string, i int) int {
+	for j := i + 1; j < len(layout); j++ {
+		switch layout[j] {
+		case ']':
+			return j
+		case '[':
+			return -1
+		}
+	}
+	return -1
+}
+
+// nextMomentChunk finds the first occurrence of a std string of the Moment
+// dialect in layout, and returns the text before, the std string, and the
+// text after. Text in brackets, a character after a backslash and letters
+// without a meaning are literal.
+func nextMomentChunk(layout string) (prefix string, std int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		switch {
+		case c == '[':
+			if j := momentLiteralEnd(layout, i); j > 0 {
+				layout = layout[:i] + layout[i+1:j] + layout[j+1:]
+				i = j - 2
+			}
+			continue
+		case c == '\\' && i+1 < len(layout):
+			layout = layout[:i] + layout[i+1:]
+			continue
+		}
+		j := 1
+		for i+j < len(layout) && layout[i+j] == c {
+			j++
+		}
+		if std, n, ordinal := momentStd(c, j); std != 0 {
+			end := i + n
+			if ordinal && end < len(layout) && layout[end] == 'o' {
+				std |= stdOrdinal
+				end++
+			}
+			return layout[0:i], std, layout[end:]
+		}
+		i += j - 1
+	}
+	return layout, 0, ""
+}
+
+// momentStd returns the std value of the longest token of the Moment
+// dialect that the run of j letters c starts with, the number of letters
+// it takes, and whether it may be followed by "o" for an ordinal number.
+func momentStd(c byte, j int) (std, n int, ordinal bool) {
+	n = j
+	if n > 4 {
+		n = 4
+	}
+	switch c {
+	case 'M':
+		switch n {
+		case 1:
+			return stdNumMonth | 1<<stdArgShift, 1, true
+		case 2:
+			return stdZeroMonth | 2<<stdArgShift, 2, false
+		case 3:
+			return stdMonth, 3, false
+		}
+		return stdLongMonth, 4, false
+	case 'Q':
+		return stdQuarter | 1<<stdArgShift, 1, true
+	case 'D':
+		switch n {
+		case 1:
+			return stdDay | 1<<stdArgShift, 1, true
+		case 2:
+			return stdZeroDay | 2<<stdArgShift, 2, false
+		case 3:
+			return stdYearDay | 1<<stdArgShift, 3, true
+		}
+		return stdYearDay | 3<<stdArgShift, 4, false
+	case 'd':
+		switch n {
+		case 1:
+			return stdNumWeekDay | 1<<stdArgShift, 1, true
+		case 2:
+			return stdWeekDay | 2<<stdArgShift, 2, false
+		case 3:
+			return stdWeekDay, 3, false
+		}
+		return stdLongWeekDay, 4, false
+	case 'e':
+		// The day of week of the English locale, Sunday being 0.
+		return stdNumWeekDay | 1<<stdArgShift, 1, false
+	case 'E':
+		return stdISOWeekDay | 1<<stdArgShift, 1, false
+	case 'W':
+		if n == 1 {
+			return stdWeek | 1<<stdArgShift, 1, true
+		}
+		return stdWeek | 2<<stdArgShift, 2, false
+	case 'G':
+		switch {
+		case j >= 5:
+			return stdWeekYear | 5<<stdArgShift, 5, false
+		case j == 4:
+			return stdWeekYear | 4<<stdArgShift, 4, false
+		case j >= 2:
+			return stdWeekYear | 2<<stdArgShift, 2, false
+		}
+	case 'Y':
+		switch {
+		case j >= 6:
+			return stdLongYear | 6<<stdArgShift | stdSignPad, 6, false
+		case j >= 4:
+			return stdLongYear | j<<stdArgShift, j, false
+		case j >= 2:
+			return stdYear | 2<<stdArgShift, 2, false
+		}
+		// At least four digits, and a sign beyond 9999.
+		return stdLongYear | 4<<stdArgShift | stdSignPad, 1, false
+	case 'y':
+		if n == 2 {
+			return stdYear | 2<<stdArgShift | stdYearOfEra, 2, false
+		}
+		return stdLongYear | n<<stdArgShift | stdYearOfEra, n, false
+	case 'N':
+		switch {
+		case j >= 5:
+			// English narrow era names are the short ones.
+			return stdEra, 5, false
+		case j == 4:
+			return stdEra | 4<<stdArgShift, 4, false
+		}
+		return stdEra, j, false
+	case 'A':
+		return stdPM, 1, false
+	case 'a':
+		return stdpm, 1, false
+	case 'H':
+		if n == 1 {
+			return stdHour | 1<<stdArgShift, 1, false
+		}
+		return stdHour | 2<<stdArgShift, 2, false
+	case 'h':
+		if n == 1 {
+			return stdHour12 | 1<<stdArgShift, 1, false
+		}
+		return stdZeroHour12 | 2<<stdArgShift, 2, false
+	case 'k':
+		if n == 1 {
+			return stdHour24 | 1<<stdArgShift, 1, false
+		}
+		return stdHour24 | 2<<stdArgShift, 2, false
+	case 'm':
+		if n == 1 {
+			return stdMinute | 1<<stdArgShift, 1, false
+		}
+		return stdZeroMinute | 2<<stdArgShift, 2, false
+	case 's':
+		if n == 1 {
+			return stdSecond | 1<<stdArgShift, 1, false
+		}
+		return stdZeroSecond | 2<<stdArgShift, 2, false
+	case 'S':
+		// Digits of the fraction, truncated.
+		if j > 9 {
+			j = 9
+		}
+		return stdFracSecond0 | j<<stdArgShift, j, false
+	case 'X':
+		return stdEpoch, 1, false
+	case 'x':
+		return stdEpoch | 3<<stdArgShift, 1, false
+	case 'Z':
+		if n == 1 {
+			return stdNumColonTZ | stdZeroZ, 1, false
+		}
+		return stdNumTZ | stdZeroZ, 2, false
+	case 'z':
+		if n == 1 {
+			return stdTZ, 1, false
+		}
+		return stdTZ, 2, false
+	case 'g', 'w':
+		// Locale weeks, whose first day depends on the locale.
+		return stdUnsupported, j, false
+	}
+	return 0, 0, false
+}
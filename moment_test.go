@@ -0,0 +1,89 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var momentTests = []FormatTest{
+	{"date", "YYYY-MM-DD", "2009-02-04"},
+	{"ordinal day", "Do MMMM YYYY", "4th February 2009"},
+	{"ordinals", "Qo DDDo Mo Wo do", "1st 35th 2nd 6th 3rd"},
+	{"weekdays", "dddd ddd dd d e E", "Wednesday Wed We 3 3 3"},
+	{"hours", "h:mm A a k kk H HH", "9:00 PM pm 21 21 21 21"},
+	{"epoch", "x X", "1233810057012 1233810057"},
+	{"fractions", "S SSS SSSSSS", "0 012 012345"},
+	{"zones", "Z ZZ", "-08:00 -0800"},
+	{"iso week", "GGGG-[W]WW-E", "2009-W06-3"},
+	{"era", "N NNNN y", "AD Anno Domini 2009"},
+	{"brackets", "[Today is] dddd", "Today is Wednesday"},
+	{"escape", `\YYY`, "Y09"},
+	{"unknown letters", "YYYY-MM-DDTHH:mm", "2009-02-04T21:00"},
+	{"local formats", "LLLL", "Wednesday, February 4, 2009 9:00 PM"},
+	{"short local formats", "LTS l ll", "9:00:57 PM 2/4/2009 Feb 4, 2009"},
+	{"bracketed local format", "[LT] LT", "LT 9:00 PM"},
+}
+
+func TestMomentFormat(t *testing.T) {
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	for _, test := range momentTests {
+		result := Format(tm, test.format, WithDialect(Moment))
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	ordinals := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 31: "31st"}
+	for day, ordinal := range ordinals {
+		if result := Format(time.Date(2009, time.January, day, 0, 0, 0, 0, time.UTC), "Do", WithDialect(Moment)); result != ordinal {
+			t.Errorf("day %d expected %q got %q", day, ordinal, result)
+		}
+	}
+}
+
+func TestMomentParse(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  string
+		result string
+	}{
+		{"YYYY-MM-DD HH:mm:ss.SSS Z", "2009-02-04 21:00:57.012 -08:00", "2009-02-05T05:00:57.012+00:00"},
+		{"YYYY-MM-DDTHH:mm:ssZ", "2009-02-04T21:00:57Z", "2009-02-04T21:00:57.000+00:00"},
+		{"Do MMMM YYYY", "4th February 2009", "2009-02-04T00:00:00.000+00:00"},
+		{"Do MMM YY", "21st Feb 09", "2009-02-21T00:00:00.000+00:00"},
+		{"Do MMM YYYY", "11th Feb 2009", "2009-02-11T00:00:00.000+00:00"},
+		{"dddd, MMMM Do YYYY, h:mm:ss a", "Wednesday, February 4th 2009, 9:00:57 pm", "2009-02-04T21:00:57.000+00:00"},
+		{"dd MM/DD/YY", "We 02/04/69", "1969-02-04T00:00:00.000+00:00"},
+		{"X", "1233810057", "2009-02-05T05:00:57.000+00:00"},
+		{"x", "1233810057012", "2009-02-05T05:00:57.012+00:00"},
+		{"GGGG-[W]WW-E", "2009-W06-3", "2009-02-04T00:00:00.000+00:00"},
+		{"L LT", "02/04/2009 9:00 PM", "2009-02-04T21:00:00.000+00:00"},
+		{"YYYY DDDo", "2009 35th", "2009-02-04T00:00:00.000+00:00"},
+	}
+	for _, test := range tests {
+		tm, err := Parse(test.layout, test.value, WithDialect(Moment))
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.layout, test.value, err)
+			continue
+		}
+		if result := Format(tm.UTC(), "YYYY-MM-DDTHH:mm:ss.SSSZ", WithDialect(Moment)); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.layout, test.value, test.result, result)
+		}
+	}
+
+	for layout, value := range map[string]string{
+		"YYYY-MM-DD":  "2009-02-30",
+		"Do MMM YY":   "21 Feb 09",
+		"Do MMM YYYY": "1th Feb 2009",
+		"gggg-ww":     "2009-06",
+	} {
+		if _, err := Parse(layout, value, WithDialect(Moment)); err == nil {
+			t.Errorf("%s %q expected an error", layout, value)
+		}
+	}
+	if result := Format(time.Date(2009, time.February, 4, 0, 0, 0, 0, time.UTC), "gggg-ww", WithDialect(Moment)); result != "gggg-ww" {
+		t.Errorf("gggg-ww expected %q got %q", "gggg-ww", result)
+	}
+}
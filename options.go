@@ -0,0 +1,179 @@
+package jodatime
+
+import "time"
+
+// An Option configures how a layout is parsed or formatted.
+type Option func(*options)
+
+type options struct {
+	dialect        Dialect
+	dst            DSTPolicy
+	zones          ZoneResolver
+	ambiguousZones bool // reject abbreviations with several zones
+	fixedZone      bool // keep numeric offsets as fixed zones
+	locations      LocationLoader
+	zone           *time.Location
+	offsetParsed   bool // keep the parsed zone despite zone
+	yearDigits     int  // format years signed with at least this many digits
+	zeroOffsetZ    bool // format a zero offset of Z and ZZ as "Z"
+	twoDigitStart  int  // first year of two-digit years, if twoDigitSet
+	twoDigitSet    bool // else they start 80 years ago
+	fraction       FractionParsing
+	fracRounding   RoundingMode // for fractional digits beyond nanoseconds
+	rounding       RoundingMode // for formatting fractional seconds
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithDialect sets the pattern language of the layout. The default is Joda.
+func WithDialect(d Dialect) Option {
+	return func(o *options) {
+		o.dialect = d
+	}
+}
+
+// WithDSTPolicy sets how a parsed wall time that falls into a daylight
+// saving gap or overlap is resolved. The default is DSTCompatible.
+func WithDSTPolicy(p DSTPolicy) Option {
+	return func(o *options) {
+		o.dst = p
+	}
+}
+
+// WithZoneResolver sets the resolver used to look up parsed time zone
+// abbreviations. The default resolves JavaShortIDs, then the abbreviations
+// of Abbreviations it lacks, such as "EDT" and "CEST"; Abbreviations alone
+// resolves all standard and daylight saving abbreviations it has.
+func WithZoneResolver(r ZoneResolver) Option {
+	return func(o *options) {
+		o.zones = r
+	}
+}
+
+// RejectAmbiguousZones makes parsing fail when the zone resolver returns
+// more than one zone for an abbreviation, rather than using the first one.
+func RejectAmbiguousZones() Option {
+	return func(o *options) {
+		o.ambiguousZones = true
+	}
+}
+
+// KeepFixedZone makes a parsed numeric offset always yield a fixed zone.
+// By default the local location, or UTC, is used instead when its offset
+// at the parsed instant matches, and so does its abbreviation if the value
+// has one, so that later arithmetic on the time follows its daylight
+// saving rules.
+func KeepFixedZone() Option {
+	return func(o *options) {
+		o.fixedZone = true
+	}
+}
+
+// WithLocationLoader sets the loader used for the locations of parsed time
+// zones, for example one reading embedded tzdata. The loader is called on
+// every parse; wrap it with NewLocationCache unless it caches by itself.
+// The default is a shared LocationCache in front of time.LoadLocation.
+func WithLocationLoader(l LocationLoader) Option {
+	return func(o *options) {
+		o.locations = l
+	}
+}
+
+// WithZone makes parsed times be converted to loc, and times be formatted
+// in loc, like Joda's DateTimeFormatter.withZone. Parsed values without a
+// time zone are interpreted in loc. It undoes WithOffsetParsed.
+func WithZone(loc *time.Location) Option {
+	return func(o *options) {
+		o.zone = loc
+		o.offsetParsed = false
+	}
+}
+
+// WithOffsetParsed makes parsed times keep the time zone or offset of the
+// value, like Joda's DateTimeFormatter.withOffsetParsed. This is the default
+// unless WithZone is given, whose location then only applies to values
+// without a time zone.
+func WithOffsetParsed() Option {
+	return func(o *options) {
+		o.offsetParsed = true
+	}
+}
+
+// WithExpandedYear makes full years, such as yyyy, be formatted with an explicit
+// sign and at least digits digits, as in the ISO 8601 expanded
+// representation "+012345-01-01". Parsing accepts signed years of any
+// length regardless of this option.
+func WithExpandedYear(digits int) Option {
+	return func(o *options) {
+		o.yearDigits = digits
+	}
+}
+
+// WithFractionParsing sets how many digits a fractional second accepts.
+// The default is FractionExact.
+func WithFractionParsing(p FractionParsing) Option {
+	return func(o *options) {
+		o.fraction = p
+	}
+}
+
+// WithFractionRounding makes parsing accept fractional seconds with more
+// than nine digits, rounding them to nanoseconds by mode. By default such
+// values are rejected.
+func WithFractionRounding(mode RoundingMode) Option {
+	return func(o *options) {
+		o.fracRounding = mode
+	}
+}
+
+// WithRounding sets how the fractional second is rounded to the number of
+// S letters when formatting. Rounding may carry into the seconds, minutes
+// and the date; a layout without S is not rounded. The default is
+// RoundTruncate.
+func WithRounding(mode RoundingMode) Option {
+	return func(o *options) {
+		o.rounding = mode
+	}
+}
+
+// WithTwoDigitYearStart makes two-digit years of the SimpleDateFormat
+// dialect parse into the 100 years from start, like SimpleDateFormat's
+// set2DigitYearStart. The default starts 80 years before the current year.
+func WithTwoDigitYearStart(start int) Option {
+	return func(o *options) {
+		o.twoDigitStart, o.twoDigitSet = start, true
+	}
+}
+
+// withZeroOffsetZ makes Z and ZZ format a zero offset as "Z", as Joda's
+// ISODateTimeFormat does.
+func withZeroOffsetZ(o *options) {
+	o.zeroOffsetZ = true
+}
+
+func (o *options) twoDigitYearStart() int {
+	if !o.twoDigitSet {
+		return time.Now().Year() - 80
+	}
+	return o.twoDigitStart
+}
+
+func (o *options) zoneResolver() ZoneResolver {
+	if o.zones == nil {
+		return defaultZones
+	}
+	return o.zones
+}
+
+func (o *options) locationLoader() LocationLoader {
+	if o.locations == nil {
+		return defaultLocations
+	}
+	return o.locations
+}
@@ -0,0 +1,130 @@
+package jodatime
+
+import (
+	"strconv"
+	"time"
+)
+
+// ParseErrorReason tells why a value does not parse.
+type ParseErrorReason int
+
+const (
+	// ReasonBadText is text that does not match the layout, like letters
+	// for a number or a month name that does not exist.
+	ReasonBadText ParseErrorReason = iota
+	// ReasonOutOfRange is a number out of the range of its field, like
+	// month 13 or February 30.
+	ReasonOutOfRange
+	// ReasonExtraText is text left after the end of the layout.
+	ReasonExtraText
+	// ReasonMissing is a value that ends before the layout does.
+	ReasonMissing
+	// ReasonAmbiguousZone is a time zone abbreviation that stands for
+	// several zones, when RejectAmbiguousZones rejects those.
+	ReasonAmbiguousZone
+	// ReasonBadLayout is a layout element the dialect does not have, like
+	// "VVV" or "b" in java.time patterns.
+	ReasonBadLayout
+	// ReasonSkippedTime is a wall time in a daylight saving gap, when
+	// DSTReject rejects it.
+	ReasonSkippedTime
+	// ReasonAmbiguousTime is a wall time in a daylight saving overlap,
+	// when DSTReject rejects it.
+	ReasonAmbiguousTime
+)
+
+var reasonNames = []string{"bad text", "out of range", "extra text", "missing", "ambiguous zone", "bad layout", "skipped time", "ambiguous time"}
+
+// String returns the English description of r, such as "out of range".
+func (r ParseErrorReason) String() string {
+	if r < 0 || int(r) >= len(reasonNames) {
+		return "unknown"
+	}
+	return reasonNames[r]
+}
+
+// A ParseError describes a problem parsing a time string. Its message is
+// built from Field, Expected and Reason. It holds a time.ParseError, with
+// LayoutElem in the layout's own terms, which errors.As finds; the error
+// itself is not a *time.ParseError, so a type assertion for one fails
+// where it used to succeed. Unwrap returns Err.
+type ParseError struct {
+	time.ParseError
+	Field    string           // the field that does not parse, as named by LayoutRegexp; empty for literal and extra text
+	Offset   int              // byte offset in Value where the problem is
+	Expected string           // the Joda layout element expected, such as "dd" or "'T'"; empty if there is none
+	Reason   ParseErrorReason // why the value does not parse
+	Err      error            // the error behind Reason, such as a *DSTError; nil if none
+}
+
+// Error returns the message of e, such as
+// `parsing time "2009-13-04" as "yyyy-MM-dd": month out of range at offset 5`.
+func (e *ParseError) Error() string {
+	msg := "parsing time " + quote(e.Value) + " as " + quote(e.Layout) + ": "
+	what := e.Field
+	if what == "" {
+		what = "value"
+	}
+	switch e.Reason {
+	case ReasonBadText:
+		msg += "cannot parse " + quote(e.ValueElem)
+		if e.Expected != "" {
+			msg += " as " + quote(e.Expected)
+		}
+	case ReasonMissing:
+		msg += "missing " + what
+		if e.Expected != "" {
+			msg += " " + quote(e.Expected)
+		}
+	case ReasonExtraText:
+		msg += "extra text " + quote(e.ValueElem)
+	case ReasonAmbiguousZone:
+		msg += "ambiguous time zone " + quote(e.ValueElem)
+	case ReasonBadLayout:
+		msg += "unsupported pattern letters " + quote(e.Expected)
+	case ReasonSkippedTime, ReasonAmbiguousTime:
+		if d, ok := e.Err.(*DSTError); ok {
+			msg += d.detail()
+		} else {
+			msg += what + " " + e.Reason.String()
+		}
+	default:
+		msg += what + " " + e.Reason.String()
+	}
+	return msg + " at offset " + strconv.Itoa(e.Offset)
+}
+
+// Unwrap returns the error behind e, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// As sets target to the time.ParseError of e if it is a **time.ParseError,
+// for errors.As.
+func (e *ParseError) As(target interface{}) bool {
+	if p, ok := target.(**time.ParseError); ok {
+		*p = &e.ParseError
+		return true
+	}
+	return false
+}
+
+// jodaElem returns the Joda layout element that std, with its width and
+// flags, stands for in the dialect d, given its text there.
+func (d Dialect) jodaElem(std int, text string) string {
+	if d == Joda {
+		return text
+	}
+	if s := lookupToken(std, func(t layoutToken) string { return t.joda }); s != "" {
+		return s
+	}
+	return text
+}
+
+// missingOr returns ReasonMissing if value is empty, and reason otherwise.
+func missingOr(value string, reason ParseErrorReason) ParseErrorReason {
+	if value == "" {
+		return ReasonMissing
+	}
+	return reason
+}
@@ -0,0 +1,98 @@
+package jodatime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var parseErrorTests = []struct {
+	layout   string
+	value    string
+	opts     []Option
+	field    string
+	offset   int
+	expected string
+	reason   ParseErrorReason
+	message  string
+}{
+	{"yyyy-MM-dd", "2009-13-04", nil, "month", 5, "MM", ReasonOutOfRange,
+		`parsing time "2009-13-04" as "yyyy-MM-dd": month out of range at offset 5`},
+	{"yyyy-MM-dd", "2009-02-30", nil, "day", 8, "dd", ReasonOutOfRange,
+		`parsing time "2009-02-30" as "yyyy-MM-dd": day out of range at offset 8`},
+	{"xxxx-'W'ww-e", "2010-W53-1", nil, "week", 6, "ww", ReasonOutOfRange,
+		`parsing time "2010-W53-1" as "xxxx-'W'ww-e": week out of range at offset 6`},
+	{"yyyy-MMM-dd", "2009-Fev-04", nil, "month", 5, "MMM", ReasonBadText,
+		`parsing time "2009-Fev-04" as "yyyy-MMM-dd": cannot parse "Fev-04" as "MMM" at offset 5`},
+	{"yyyy-MM-dd'T'HH", "2009-02-04 21", nil, "", 10, "'T'", ReasonBadText,
+		`parsing time "2009-02-04 21" as "yyyy-MM-dd'T'HH": cannot parse " 21" as "'T'" at offset 10`},
+	{"yyyy-MM-dd", "2009-02-04 21:00", nil, "", 10, "", ReasonExtraText,
+		`parsing time "2009-02-04 21:00" as "yyyy-MM-dd": extra text " 21:00" at offset 10`},
+	{"yyyy-MM-dd HH:mm", "2009-02-04", nil, "hour", 10, "HH", ReasonMissing,
+		`parsing time "2009-02-04" as "yyyy-MM-dd HH:mm": missing hour "HH" at offset 10`},
+	{"%Y-%m-%d", "2009-13-04", []Option{WithDialect(Strftime)}, "month", 5, "MM", ReasonOutOfRange,
+		`parsing time "2009-13-04" as "%Y-%m-%d": month out of range at offset 5`},
+	{"yyyy-MM-dd HH:mm ZZZ", "2010-02-04 21:00 CST", []Option{WithZoneResolver(ZoneResolvers{NorthAmerica, AsiaPacific}), RejectAmbiguousZones()}, "zone", 17, "ZZZ", ReasonAmbiguousZone,
+		`parsing time "2010-02-04 21:00 CST" as "yyyy-MM-dd HH:mm ZZZ": ambiguous time zone "CST" at offset 17`},
+}
+
+func TestParseError(t *testing.T) {
+	for _, test := range parseErrorTests {
+		_, err := Parse(test.layout, test.value, test.opts...)
+		var e *ParseError
+		if !errors.As(err, &e) {
+			t.Errorf("%s %q: expected a *ParseError got %v", test.layout, test.value, err)
+			continue
+		}
+		if e.Field != test.field || e.Offset != test.offset || e.Expected != test.expected || e.Reason != test.reason {
+			t.Errorf("%s %q: expected %q at %d expecting %q, %v got %q at %d expecting %q, %v", test.layout, test.value,
+				test.field, test.offset, test.expected, test.reason, e.Field, e.Offset, e.Expected, e.Reason)
+		}
+		if err.Error() != test.message {
+			t.Errorf("%s %q: expected message %q got %q", test.layout, test.value, test.message, err.Error())
+		}
+		var te *time.ParseError
+		if !errors.As(err, &te) || te.Value != test.value {
+			t.Errorf("%s %q: expected a *time.ParseError got %v", test.layout, test.value, te)
+		}
+		if _, ok := err.(*time.ParseError); ok {
+			t.Errorf("%s %q: expected no *time.ParseError without errors.As", test.layout, test.value)
+		}
+	}
+}
+
+func TestParseErrorDST(t *testing.T) {
+	for _, test := range []struct {
+		value   string
+		reason  ParseErrorReason
+		message string
+	}{
+		{"2024-03-10 02:30", ReasonSkippedTime,
+			`parsing time "2024-03-10 02:30" as "yyyy-MM-dd HH:mm": wall time skipped by daylight saving transition in America/Los_Angeles at offset 11`},
+		{"2024-11-03 01:30", ReasonAmbiguousTime,
+			`parsing time "2024-11-03 01:30" as "yyyy-MM-dd HH:mm": wall time repeated by daylight saving transition in America/Los_Angeles at offset 11`},
+	} {
+		_, err := ParseInLocation("yyyy-MM-dd HH:mm", test.value, local, WithDSTPolicy(DSTReject))
+		var e *ParseError
+		if !errors.As(err, &e) {
+			t.Errorf("%q: expected a *ParseError got %v", test.value, err)
+			continue
+		}
+		if e.Field != "hour" || e.Offset != 11 || e.Expected != "HH" || e.Reason != test.reason {
+			t.Errorf("%q: expected hour at 11 expecting HH, %v got %q at %d expecting %q, %v", test.value, test.reason, e.Field, e.Offset, e.Expected, e.Reason)
+		}
+		if err.Error() != test.message {
+			t.Errorf("%q: expected message %q got %q", test.value, test.message, err.Error())
+		}
+		var de *DSTError
+		if !errors.As(err, &de) || de.Overlap != (test.reason == ReasonAmbiguousTime) {
+			t.Errorf("%q: expected a *DSTError got %v", test.value, de)
+		}
+		var te *time.ParseError
+		if !errors.As(err, &te) || te.Value != test.value {
+			t.Errorf("%q: expected a *time.ParseError got %v", test.value, te)
+		}
+	}
+}
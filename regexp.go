@@ -0,0 +1,306 @@
+package jodatime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LayoutRegexp returns a regular expression that matches the text Parse
+// accepts for layout, for finding times within other text. It is not
+// anchored. Each field is a named group: year, era, quarter, month, day,
+// weekday, hour, minute, second, fraction, ampm, zone, yearDay, weekYear,
+// week, monthWeek, dayInMonth, milliOfDay, nanoOfDay and epoch; a field
+// that appears again gets a number, as in "month2". The expression checks
+// the form of the text only, not the ranges of its numbers.
+func LayoutRegexp(layout string, opts ...Option) (*regexp.Regexp, error) {
+	o := newOptions(opts)
+	return regexp.Compile(o.layoutRegexp(layout))
+}
+
+func (o *options) layoutRegexp(layout string) string {
+	var b strings.Builder
+	seen := make(map[string]int)
+	layout = o.dialect.expand(layout)
+	for {
+		prefix, std, suffix := o.dialect.next(layout)
+		b.WriteString(literalRegexp(prefix))
+		if std == 0 {
+			break
+		}
+		layout = suffix
+		width := std >> stdArgShift & stdWidthMask
+		flags := std
+		std &= stdMask
+		if isNumber(std) && o.dialect.lenient() && o.dialect.reservedDigits(layout) == 0 {
+			// Any number of digits.
+			width = 0
+		}
+
+		if flags&stdSpacePad != 0 {
+			b.WriteString(" *")
+		}
+		name := fieldName(std)
+		if seen[name]++; seen[name] > 1 {
+			name += strconv.Itoa(seen[name])
+		}
+		b.WriteString("(?P<" + name + ">" + o.stdRegexp(std, width, flags) + ")")
+		if flags&stdOrdinal != 0 {
+			b.WriteString("(?:st|nd|rd|th)")
+		}
+		if std == stdSecond || std == stdZeroSecond || std == stdEpoch {
+			// A fractional second the layout does not have, as parse
+			// takes it after seconds and epochs.
+			_, next, _ := o.dialect.next(layout)
+			switch next & stdMask {
+			case stdFracSecond0, stdFracSecond9, stdNanoSecond, stdMillisecond:
+			default:
+				b.WriteString(`(?:\.\d+)?`)
+			}
+		}
+	}
+	return b.String()
+}
+
+// literalRegexp returns a regular expression for the literal text of a
+// layout. Like skip, it takes a run of spaces for any number of them.
+func literalRegexp(text string) string {
+	var b strings.Builder
+	for text != "" {
+		i := strings.IndexByte(text, ' ')
+		if i < 0 {
+			b.WriteString(regexp.QuoteMeta(text))
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(text[:i]))
+		b.WriteString(" +")
+		text = cutspace(text[i:])
+	}
+	return b.String()
+}
+
+// fieldName returns the name of the capture group of std.
+func fieldName(std int) string {
+	switch std {
+	case stdYear, stdLongYear:
+		return "year"
+	case stdEra:
+		return "era"
+	case stdQuarter, stdQuarterText:
+		return "quarter"
+	case stdMonth, stdLongMonth, stdNumMonth, stdZeroMonth:
+		return "month"
+	case stdDay, stdUnderDay, stdZeroDay:
+		return "day"
+	case stdWeekDay, stdLongWeekDay, stdISOWeekDay, stdNumWeekDay:
+		return "weekday"
+	case stdHour, stdHour12, stdZeroHour12, stdHour11, stdHour24:
+		return "hour"
+	case stdMinute, stdZeroMinute:
+		return "minute"
+	case stdSecond, stdZeroSecond:
+		return "second"
+	case stdFracSecond0, stdFracSecond9, stdNanoSecond, stdMillisecond:
+		return "fraction"
+	case stdPM, stdpm:
+		return "ampm"
+	case stdYearDay:
+		return "yearDay"
+	case stdWeekYear:
+		return "weekYear"
+	case stdWeek, stdSundayWeek, stdMondayWeek:
+		return "week"
+	case stdWeekOfMonth:
+		return "monthWeek"
+	case stdWeekDayInMonth:
+		return "dayInMonth"
+	case stdMilliOfDay:
+		return "milliOfDay"
+	case stdNanoOfDay:
+		return "nanoOfDay"
+	case stdEpoch:
+		return "epoch"
+	}
+	return "zone"
+}
+
+// stdRegexp returns a regular expression for the text parse accepts for
+// std, whose argument is split into width and flags.
+func (o *options) stdRegexp(std, width, flags int) string {
+	switch std {
+	case stdYear:
+		if flags&stdBaseNow != 0 {
+			return `[+-]?\d{1,9}`
+		}
+		return `\d{2}`
+	case stdLongYear:
+		return `[+-]?\d{1,9}`
+	case stdEra:
+		return namesRegexp(eraNames(width))
+	case stdQuarter:
+		return numRegexp(width, width > 1)
+	case stdQuarterText:
+		if width == 4 {
+			return namesRegexp(longQuarterNames)
+		}
+		return `Q\d{1,2}`
+	case stdMonth:
+		if width == 5 {
+			return namesRegexp(narrowMonthNames)
+		}
+		return namesRegexp(shortMonthNames)
+	case stdLongMonth:
+		return namesRegexp(longMonthNames)
+	case stdNumMonth, stdZeroMonth:
+		return numRegexp(width, std == stdZeroMonth)
+	case stdWeekDay:
+		switch width {
+		case 5:
+			return namesRegexp(narrowDayNames)
+		case 2:
+			return namesRegexp(minDayNames)
+		}
+		return namesRegexp(shortDayNames)
+	case stdLongWeekDay:
+		return namesRegexp(longDayNames)
+	case stdUnderDay:
+		return ` ?` + numRegexp(width, false)
+	case stdDay, stdZeroDay:
+		return numRegexp(width, std == stdZeroDay)
+	case stdHour:
+		return numRegexp(width, false)
+	case stdHour12, stdZeroHour12:
+		return numRegexp(width, std == stdZeroHour12)
+	case stdHour11, stdHour24:
+		return numRegexp(width, width > 1)
+	case stdMilliOfDay:
+		return digitsRegexp(width, 8)
+	case stdNanoOfDay:
+		return digitsRegexp(width, 14)
+	case stdNanoSecond:
+		return digitsRegexp(width, 9)
+	case stdMinute, stdZeroMinute:
+		return numRegexp(width, std == stdZeroMinute)
+	case stdSecond, stdZeroSecond:
+		return numRegexp(width, std == stdZeroSecond)
+	case stdPM:
+		return `(?i:AM|PM)`
+	case stdpm:
+		return `am|pm`
+	case stdISO8601TZ, stdISO8601ColonTZ, stdISO8601SecondsTZ, stdISO8601ShortTZ, stdISO8601ColonSecondsTZ, stdNumTZ, stdNumShortTZ, stdNumColonTZ, stdNumSecondsTz, stdNumColonSecondsTZ:
+		var s string
+		optional := ""
+		if flags&stdOptional != 0 {
+			optional = "?"
+		}
+		switch std {
+		case stdNumTZ:
+			// A colon is accepted, and hours alone at the end of the value.
+			s = `[+-]\d{2}(?::?\d{2}|$)`
+		case stdISO8601TZ:
+			s = `[+-]\d{4}`
+		case stdNumShortTZ, stdISO8601ShortTZ:
+			s = `[+-]\d{2}`
+			if optional != "" {
+				s += `(?:\d{2})?`
+			}
+		case stdNumColonTZ, stdISO8601ColonTZ:
+			s = `[+-]\d{2}:\d{2}`
+		case stdNumSecondsTz, stdISO8601SecondsTZ:
+			s = `[+-]\d{4}(?:\d{2})` + optional
+		default:
+			s = `[+-]\d{2}:\d{2}(?::\d{2})` + optional
+		}
+		if std != stdNumTZ && std != stdNumShortTZ && std != stdNumColonTZ && std != stdNumSecondsTz && std != stdNumColonSecondsTZ || flags&stdZeroZ != 0 {
+			s = `Z|` + s
+		}
+		return s
+	case stdTZ:
+		return `UTC|ChST|MeST|WITA|GMT(?:[+-]\d{1,2})?|[+-]\d{1,2}|[A-Z]{3}(?:[A-Z]?T)?`
+	case stdZoneID:
+		return `Z|[+-]\d{2}:\d{2}|[A-Za-z][A-Za-z0-9~/._+-]+`
+	case stdGMTOffset:
+		if width == 4 {
+			return `GMT(?:[+-]\d{2}:\d{2}(?::\d{2})?)?`
+		}
+		return `GMT(?:[+-]\d{1,2}(?::\d{2}(?::\d{2})?)?)?`
+	case stdFracSecond0:
+		switch {
+		case flags&stdVarDigits != 0:
+			return digitsRegexp(1, width)
+		case o.fraction == FractionLenient:
+			return o.fractionRegexp(1)
+		}
+		return digitsRegexp(width, width)
+	case stdFracSecond9:
+		return o.fractionRegexp(0)
+	case stdYearDay:
+		if width < 3 {
+			return numRegexp(3, false)
+		}
+		return numRegexp(width, true)
+	case stdWeekYear:
+		if width == 2 {
+			return `\d{2}`
+		}
+		return `[+-]?\d{1,9}`
+	case stdWeek, stdISOWeekDay:
+		return numRegexp(width, width > 1)
+	case stdWeekOfMonth, stdWeekDayInMonth, stdNumWeekDay, stdSundayWeek, stdMondayWeek:
+		return numRegexp(width, false)
+	case stdMillisecond:
+		return numRegexp(width, true)
+	case stdEpoch:
+		return `[+-]?\d{1,19}`
+	case stdUnsupported:
+		// Nothing parses.
+		return `[^\s\S]`
+	}
+	return ""
+}
+
+// numRegexp returns a regular expression for the digits getnumWidth
+// parses.
+func numRegexp(width int, fixed bool) string {
+	switch {
+	case width == 0:
+		return digitsRegexp(1, 9)
+	case width <= 2 && fixed:
+		return digitsRegexp(2, 2)
+	case width <= 2:
+		return digitsRegexp(1, 2)
+	case fixed:
+		return digitsRegexp(width, width)
+	}
+	return digitsRegexp(1, width)
+}
+
+// digitsRegexp returns a regular expression for the digits getdigits
+// parses.
+func digitsRegexp(min, max int) string {
+	if min < 1 {
+		min = 1
+	}
+	if min == max {
+		return `\d{` + strconv.Itoa(min) + `}`
+	}
+	return `\d{` + strconv.Itoa(min) + `,` + strconv.Itoa(max) + `}`
+}
+
+// fractionRegexp returns a regular expression for at least min of the
+// digits fractionDigits counts.
+func (o *options) fractionRegexp(min int) string {
+	if o.fracRounding != 0 {
+		return `\d{` + strconv.Itoa(min) + `,}`
+	}
+	return `\d{` + strconv.Itoa(min) + `,9}`
+}
+
+// namesRegexp returns a regular expression for the names lookup matches.
+func namesRegexp(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return `(?i:` + strings.Join(quoted, "|") + `)`
+}
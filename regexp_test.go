@@ -0,0 +1,73 @@
+package jodatime_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestLayoutRegexp(t *testing.T) {
+	re, err := LayoutRegexp("yyyy-MM-dd'T'HH:mm:ss.SSSZZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := re.FindStringSubmatch("at 2009-02-04T21:00:57.012-08:00: started")
+	if m == nil {
+		t.Fatal("no match")
+	}
+	want := map[string]string{"year": "2009", "month": "02", "day": "04", "hour": "21", "minute": "00", "second": "57", "fraction": "012", "zone": "-08:00"}
+	for name, value := range want {
+		if got := m[re.SubexpIndex(name)]; got != value {
+			t.Errorf("%s expected %q got %q", name, value, got)
+		}
+	}
+
+	re, err = LayoutRegexp("dd MMM yyyy '-' dd MMM yyyy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := re.FindStringSubmatch("04 feb 2009 - 10 Mar 2010"); m == nil || m[re.SubexpIndex("month2")] != "Mar" {
+		t.Errorf("expected second month Mar in %q", m)
+	}
+}
+
+// Text that parses must match the regular expression of its layout.
+func TestLayoutRegexpParse(t *testing.T) {
+	check := func(layout, value string, opts ...Option) {
+		if _, err := Parse(layout, value, opts...); err != nil {
+			return
+		}
+		re, err := LayoutRegexp(layout, opts...)
+		if err != nil {
+			t.Errorf("%s error: %v", layout, err)
+			return
+		}
+		if full := regexp.MustCompile(`^(?:` + re.String() + `)$`); !full.MatchString(value) {
+			t.Errorf("%s: %q does not match %s", layout, value, re)
+		}
+	}
+	for _, test := range parseTests {
+		check(test.format, test.value)
+	}
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	dialects := []struct {
+		dialect Dialect
+		tests   []FormatTest
+	}{
+		{Joda, formatTests},
+		{JavaTime, javaTimeTests},
+		{SimpleDateFormat, simpleDateFormatTests},
+		{Strftime, strftimeTests},
+		{Moment, momentTests},
+	}
+	for _, d := range dialects {
+		for _, test := range d.tests {
+			check(test.format, Format(tm, test.format, WithDialect(d.dialect)), WithDialect(d.dialect))
+		}
+	}
+	for _, test := range adjacentTests {
+		check(test.format, test.value)
+	}
+}
@@ -0,0 +1,85 @@
+package jodatime
+
+import "time"
+
+// FractionParsing selects how many digits a fractional second accepts
+// when parsing.
+type FractionParsing int
+
+const (
+	// FractionExact accepts exactly as many digits as there are S letters
+	// when there are up to three of them, and up to nine otherwise.
+	FractionExact FractionParsing = iota
+	// FractionLenient accepts one up to nine digits regardless of the
+	// number of S letters.
+	FractionLenient
+)
+
+// RoundingMode tells how digits that do not fit a fractional second are
+// dropped.
+type RoundingMode int
+
+const (
+	// RoundTruncate drops the digits.
+	RoundTruncate RoundingMode = iota + 1
+	// RoundHalfUp rounds half a unit or more up.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest unit, and half a unit to the
+	// even one.
+	RoundHalfEven
+	// RoundCeiling rounds any remainder up.
+	RoundCeiling
+)
+
+// roundUp reports whether a value whose kept digits are odd or even rounds
+// up to the next unit, given the first dropped digit and whether any of the
+// digits after it are non-zero.
+func (m RoundingMode) roundUp(odd bool, first int, sticky bool) bool {
+	switch m {
+	case RoundHalfUp:
+		return first >= 5
+	case RoundHalfEven:
+		if first != 5 {
+			return first > 5
+		}
+		return sticky || odd
+	case RoundCeiling:
+		return first > 0 || sticky
+	}
+	return false
+}
+
+// roundUpDigits is like roundUp with the dropped decimal digits given.
+func (m RoundingMode) roundUpDigits(odd bool, dropped string) bool {
+	if len(dropped) == 0 {
+		return false
+	}
+	sticky := false
+	for i := 1; i < len(dropped); i++ {
+		if dropped[i] != '0' {
+			sticky = true
+			break
+		}
+	}
+	return m.roundUp(odd, int(dropped[0]-'0'), sticky)
+}
+
+// round rounds the fractional second of t to the given number of digits.
+func (m RoundingMode) round(t time.Time, digits int) time.Time {
+	if digits >= 9 {
+		return t
+	}
+	unit := 1
+	for i := digits; i < 9; i++ {
+		unit *= 10
+	}
+	ns := t.Nanosecond()
+	rem := ns % unit
+	if rem == 0 {
+		return t
+	}
+	if m.roundUp((ns/unit)%2 == 1, rem/(unit/10), rem%(unit/10) != 0) {
+		return t.Add(time.Duration(unit - rem))
+	}
+	return t.Add(-time.Duration(rem))
+}
@@ -0,0 +1,85 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var fractionTests = []struct {
+	name   string
+	format string
+	value  string
+	opts   []Option
+	nsec   int // -1 for an error
+}{
+	{"exact", "HH:mm:ss.SSS", "21:00:57.012", nil, 12000000},
+	{"exact short", "HH:mm:ss.SSS", "21:00:57.01", nil, -1},
+	{"exact long", "HH:mm:ss.SSS", "21:00:57.012345", nil, -1},
+	{"lenient short", "HH:mm:ss.SSS", "21:00:57.01", []Option{WithFractionParsing(FractionLenient)}, 10000000},
+	{"lenient long", "HH:mm:ss.SSS", "21:00:57.012345", []Option{WithFractionParsing(FractionLenient)}, 12345000},
+	{"lenient nano", "HH:mm:ss.S", "21:00:57.012345678", []Option{WithFractionParsing(FractionLenient)}, 12345678},
+	{"excess rejected", "HH:mm:ss.SSSSSSSSS", "21:00:57.0123456789", nil, -1},
+	{"excess truncate", "HH:mm:ss.SSSSSSSSS", "21:00:57.0123456789", []Option{WithFractionRounding(RoundTruncate)}, 12345678},
+	{"excess half up", "HH:mm:ss.SSSSSSSSS", "21:00:57.0123456785", []Option{WithFractionRounding(RoundHalfUp)}, 12345679},
+	{"excess half even", "HH:mm:ss.SSSSSSSSS", "21:00:57.0123456785", []Option{WithFractionRounding(RoundHalfEven)}, 12345678},
+	{"excess half even above", "HH:mm:ss.SSSSSSSSS", "21:00:57.01234567850001", []Option{WithFractionRounding(RoundHalfEven)}, 12345679},
+	{"excess without layout", "HH:mm:ss", "21:00:57.0123456789", []Option{WithFractionRounding(RoundHalfUp)}, 12345679},
+	{"lenient excess", "HH:mm:ss.SSS", "21:00:57.0123456789", []Option{WithFractionParsing(FractionLenient), WithFractionRounding(RoundHalfUp)}, 12345679},
+}
+
+func TestParseFraction(t *testing.T) {
+	for _, test := range fractionTests {
+		tm, err := Parse(test.format, test.value, test.opts...)
+		if test.nsec < 0 {
+			if err == nil {
+				t.Errorf("%s expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+		} else if tm.Nanosecond() != test.nsec {
+			t.Errorf("%s expected %d got %d", test.name, test.nsec, tm.Nanosecond())
+		}
+	}
+
+	// Rounding carries into the seconds.
+	tm, err := Parse("HH:mm:ss.SSSSSSSSS", "21:00:59.9999999999", WithFractionRounding(RoundHalfUp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := Format(tm, "HH:mm:ss.SSS"); result != "21:01:00.000" {
+		t.Errorf("carry expected %q got %q", "21:01:00.000", result)
+	}
+}
+
+var roundingTests = []struct {
+	name   string
+	nsec   int
+	mode   RoundingMode
+	format string
+	result string
+}{
+	{"truncate", 123456789, RoundTruncate, "HH:mm:ss.SSS", "23:59:59.123"},
+	{"half up", 123500000, RoundHalfUp, "HH:mm:ss.SSS", "23:59:59.124"},
+	{"half up below", 123499999, RoundHalfUp, "HH:mm:ss.SSS", "23:59:59.123"},
+	{"half even down", 122500000, RoundHalfEven, "HH:mm:ss.SSS", "23:59:59.122"},
+	{"half even up", 123500000, RoundHalfEven, "HH:mm:ss.SSS", "23:59:59.124"},
+	{"half even above", 122500001, RoundHalfEven, "HH:mm:ss.SSS", "23:59:59.123"},
+	{"ceiling", 123000001, RoundCeiling, "HH:mm:ss.SSS", "23:59:59.124"},
+	{"ceiling exact", 123000000, RoundCeiling, "HH:mm:ss.SSS", "23:59:59.123"},
+	{"carry", 999500000, RoundHalfUp, "yyyy-MM-dd HH:mm:ss.SSS", "2010-01-01 00:00:00.000"},
+	{"no fraction", 500000000, RoundHalfUp, "yyyy-MM-dd HH:mm:ss", "2009-12-31 23:59:59"},
+	{"no fraction ceiling", 1, RoundCeiling, "yyyy-MM-dd HH:mm:ss", "2009-12-31 23:59:59"},
+}
+
+func TestFormatRounding(t *testing.T) {
+	for _, test := range roundingTests {
+		tm := time.Date(2009, time.December, 31, 23, 59, 59, test.nsec, time.UTC)
+		if result := Format(tm, test.format, WithRounding(test.mode)); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package jodatime
+
+import (
+	"bufio"
+	"bytes"
+	"time"
+)
+
+// ScanRecords returns a split function for a bufio.Scanner that returns
+// each record of a log without its final line ending. A record starts with
+// a line that begins with a time in layout, as ParsePrefix parses it, and
+// goes on with the lines that do not, such as those of a stack trace. Lines
+// before the first time make a record of their own. A record longer than
+// the buffer of the Scanner fails with bufio.ErrTooLong. The split function
+// remembers the lines it has checked, so it serves a single Scanner.
+func ScanRecords(layout string, opts ...Option) bufio.SplitFunc {
+	o := newOptions(opts)
+	startsRecord := func(line []byte) bool {
+		_, n, err := parsePrefix(layout, string(line), time.UTC, time.Local, &o)
+		return err == nil && n > 0
+	}
+	// The line ending in data after which lines are yet to be checked, or
+	// 0 if none is checked. The Scanner calls again with the same data and
+	// more until a token is returned.
+	scanned := 0
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		i := bytes.IndexByte(data, '\n')
+		if scanned > 0 {
+			i = scanned
+		}
+		for i >= 0 {
+			start := i + 1
+			end := bytes.IndexByte(data[start:], '\n')
+			if end < 0 {
+				if !atEOF {
+					// Wait for the whole line.
+					scanned = i
+					return 0, nil, nil
+				}
+				end = len(data)
+			} else {
+				end += start
+			}
+			if startsRecord(data[start:end]) {
+				scanned = 0
+				return start, dropLineEnd(data[:start]), nil
+			}
+			if end == len(data) {
+				break
+			}
+			i = end
+		}
+		if atEOF {
+			scanned = 0
+			return len(data), dropLineEnd(data), nil
+		}
+		return 0, nil, nil
+	}
+}
+
+// dropLineEnd drops a terminal \n or \r\n from data.
+func dropLineEnd(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\n' {
+		data = data[:len(data)-1]
+		if len(data) > 0 && data[len(data)-1] == '\r' {
+			data = data[:len(data)-1]
+		}
+	}
+	return data
+}
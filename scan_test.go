@@ -0,0 +1,88 @@
+package jodatime_test
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestScanRecords(t *testing.T) {
+	log := "starting up\n" +
+		"2024-05-01 10:00:00,123 INFO ready\n" +
+		"2024-05-01 10:00:01,000 ERROR failed\r\n" +
+		"java.lang.IllegalStateException: 2024-05-01 10:00:01\r\n" +
+		"\tat Main.main(Main.java:3)\r\n" +
+		"\n" +
+		"2024-05-01 10:00:02,000 INFO done\n"
+	want := []string{
+		"starting up",
+		"2024-05-01 10:00:00,123 INFO ready",
+		"2024-05-01 10:00:01,000 ERROR failed\r\njava.lang.IllegalStateException: 2024-05-01 10:00:01\r\n\tat Main.main(Main.java:3)\r\n",
+		"2024-05-01 10:00:02,000 INFO done",
+	}
+	for _, oneByte := range []bool{false, true} {
+		var r io.Reader = strings.NewReader(log)
+		if oneByte {
+			r = iotest.OneByteReader(r)
+		}
+		s := bufio.NewScanner(r)
+		s.Split(ScanRecords("yyyy-MM-dd HH:mm:ss,SSS"))
+		var records []string
+		for s.Scan() {
+			records = append(records, s.Text())
+		}
+		if err := s.Err(); err != nil {
+			t.Errorf("one byte %v error: %v", oneByte, err)
+		}
+		if !reflect.DeepEqual(records, want) {
+			t.Errorf("one byte %v expected %q got %q", oneByte, want, records)
+		}
+	}
+
+	s := bufio.NewScanner(strings.NewReader("[04/Feb/2009:21:00:57 -0800] a\n b\n[04/Feb/2009:21:00:58 -0800] c"))
+	s.Split(ScanRecords("'['dd/MMM/yyyy:HH:mm:ss Z']'"))
+	var records []string
+	for s.Scan() {
+		records = append(records, s.Text())
+	}
+	if want := []string{"[04/Feb/2009:21:00:57 -0800] a\n b", "[04/Feb/2009:21:00:58 -0800] c"}; !reflect.DeepEqual(records, want) {
+		t.Errorf("expected %q got %q", want, records)
+	}
+}
+
+// countingZones counts the abbreviations it is asked for, and takes all
+// but PST for two zones.
+type countingZones struct{ calls int }
+
+func (c *countingZones) ResolveZone(abbr string) []Zone {
+	c.calls++
+	if abbr == "PST" {
+		return []Zone{{"", -8 * 3600, true}}
+	}
+	return []Zone{{"", 0, true}, {"", 3600, true}}
+}
+
+func TestScanRecordsLinear(t *testing.T) {
+	// Each line of a long record is checked once, however the input is
+	// read.
+	const lines = 200
+	log := "PST start\n" + strings.Repeat("ABC at Main.main\n", lines)
+	zones := &countingZones{}
+	s := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(log)))
+	s.Split(ScanRecords("ZZZ", WithZoneResolver(zones), RejectAmbiguousZones()))
+	n := 0
+	for s.Scan() {
+		n++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || zones.calls > lines {
+		t.Errorf("expected 1 record and at most %d checks got %d and %d", lines, n, zones.calls)
+	}
+}
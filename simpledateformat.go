@@ -0,0 +1,140 @@
+package jodatime
+
+import "time"
+
+// simpleDateFormatStd returns the std value of j pattern letters c of the
+// SimpleDateFormat dialect.
+func simpleDateFormatStd(c byte, j int) int {
+	width := j << stdArgShift
+	switch c {
+	case 'G':
+		return stdEra
+	case 'y':
+		// Only years of one or two letters may be two-digit years.
+		switch j {
+		case 1:
+			return stdLongYear | width | stdYearOfEra | stdBaseNow
+		case 2:
+			return stdYear | width | stdYearOfEra | stdBaseNow
+		}
+		return stdLongYear | width | stdYearOfEra
+	case 'Y':
+		return stdWeekYear | width | stdUSWeek
+	case 'M', 'L':
+		switch j {
+		case 1:
+			return stdNumMonth | width
+		case 2:
+			return stdZeroMonth | width
+		case 3:
+			return stdMonth
+		}
+		return stdLongMonth
+	case 'w':
+		return stdWeek | width | stdUSWeek
+	case 'W':
+		return stdWeekOfMonth | width | stdUSWeek
+	case 'D':
+		return stdYearDay | width
+	case 'd':
+		if j == 1 {
+			return stdDay | width
+		}
+		return stdZeroDay | width
+	case 'F':
+		return stdWeekDayInMonth | width
+	case 'E':
+		if j <= 3 {
+			return stdWeekDay
+		}
+		return stdLongWeekDay
+	case 'u':
+		return stdISOWeekDay | width
+	case 'a':
+		return stdPM
+	case 'H':
+		return stdHour | width
+	case 'k':
+		return stdHour24 | width
+	case 'K':
+		return stdHour11 | width
+	case 'h':
+		if j == 1 {
+			return stdHour12 | width
+		}
+		return stdZeroHour12 | width
+	case 'm':
+		if j == 1 {
+			return stdMinute | width
+		}
+		return stdZeroMinute | width
+	case 's':
+		if j == 1 {
+			return stdSecond | width
+		}
+		return stdZeroSecond | width
+	case 'S':
+		return stdMillisecond | width
+	case 'z':
+		return stdTZ
+	case 'Z':
+		return stdNumTZ
+	case 'X':
+		switch j {
+		case 1:
+			return stdISO8601ShortTZ
+		case 2:
+			return stdISO8601TZ
+		case 3:
+			return stdISO8601ColonTZ
+		}
+	}
+	return 0
+}
+
+// twoDigitYear returns the year within the 100 years from start that ends
+// in the two digits yy.
+func twoDigitYear(yy, start int) int {
+	year := start - start%100 + yy
+	if year < start {
+		year += 100
+	}
+	return year
+}
+
+// firstWeek returns the zero-based weekday, Monday being 0, of the first of
+// the month, and the number of the week it is in. Like ISO 8601 weeks, the
+// first week of a month is the first one with at least four of its days.
+func firstWeek(year, month int) (weekday, week int) {
+	weekday = (int(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Weekday()) + 6) % 7
+	if weekday <= 3 {
+		return weekday, 1
+	}
+	return weekday, 0
+}
+
+// usWeek returns the week-based year and week of the day of the zero-based
+// yday of year on weekday in the US locale, whose weeks start on Sunday and
+// whose week 1 holds January 1.
+func usWeek(year, yday int, weekday time.Weekday) (weekYear, week int) {
+	days := 365
+	if isLeap(year) {
+		days = 366
+	}
+	if yday-int(weekday)+7 > days {
+		// The week holds January 1 of the next year.
+		return year + 1, 1
+	}
+	jan1 := (int(weekday) - yday%7 + 7) % 7
+	return year, (yday+jan1)/7 + 1
+}
+
+// getTwoDigitYear parses a year like getsigned, taking exactly two digits
+// as a two-digit year in the 100 years from start.
+func getTwoDigitYear(s string, start int) (int, string, error) {
+	year, rest, err := getsigned(s, 9)
+	if err == nil && len(s)-len(rest) == 2 && isDigit(s, 0) && isDigit(s, 1) {
+		year = twoDigitYear(year, start)
+	}
+	return year, rest, err
+}
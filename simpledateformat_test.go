@@ -0,0 +1,141 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var simpleDateFormatTests = []FormatTest{
+	{"date time", "yyyy-MM-dd HH:mm:ss.SSS", "2009-02-04 21:00:57.012"},
+	{"short year", "yy y", "09 2009"},
+	{"milliseconds", "S SSSS", "12 0012"},
+	{"day number", "u EEE EEEE", "3 Wed Wednesday"},
+	{"week in month", "W F", "1 1"},
+	{"week year", "YYYY-'W'ww", "2009-W06"},
+	{"hours", "H k K h a", "21 21 9 9 PM"},
+	{"zones", "z Z X XX XXX", "PST -0800 -08 -0800 -08:00"},
+	{"era", "G", "AD"},
+}
+
+func TestSimpleDateFormat(t *testing.T) {
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	for _, test := range simpleDateFormatTests {
+		result := Format(tm, test.format, WithDialect(SimpleDateFormat))
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	tests := []struct {
+		layout string
+		value  string
+		result string
+	}{
+		{"yyyy-MM-dd HH:mm:ss.SSS", "2009-02-04 21:00:57.012", "2009-02-04 21:00:57.012"},
+		// Numbers take any number of digits, unless followed by a number.
+		{"yyyy-MM-dd HH:mm:ss", "2009-2-4 9:0:57", "2009-02-04 09:00:57.000"},
+		{"yyyyMMddHHmmss", "20090204210057", "2009-02-04 21:00:57.000"},
+		{"yyyy-MM-dd HH:mm:ss.SSS", "2009-02-04 21:00:57.5", "2009-02-04 21:00:57.005"},
+		// Values out of range roll over.
+		{"yyyy-MM-dd", "2009-13-32", "2010-02-01 00:00:00.000"},
+		{"yyyy-MM-dd HH:mm", "2009-02-04 24:30", "2009-02-05 00:30:00.000"},
+		// A year of more than two digits is taken literally.
+		{"yy-MM-dd", "0009-02-04", "0009-02-04 00:00:00.000"},
+		{"yyyy-MM-dd", "09-02-04", "0009-02-04 00:00:00.000"},
+		// Week of month and day of week in month.
+		{"yyyy-MM W u", "2009-02 1 3", "2009-02-04 00:00:00.000"},
+		{"yyyy-MM W EEE", "2009-03 1 Sun", "2009-03-01 00:00:00.000"},
+		{"yyyy-MM W EEE", "2009-03 0 Sun", "2009-02-22 00:00:00.000"},
+		{"yyyy-MM F EEE", "2009-02 2 Fri", "2009-02-13 00:00:00.000"},
+		{"yyyy-MM F", "2009-02 3", "2009-02-15 00:00:00.000"},
+		{"YYYY-'W'ww-u", "2009-W01-1", "2008-12-29 00:00:00.000"},
+		{"yyyy-MM-dd kk:mm", "2009-02-04 24:00", "2009-02-04 00:00:00.000"},
+		{"G yyyy-MM-dd", "BC 44-03-15", "-0043-03-15 00:00:00.000"},
+	}
+	for _, test := range tests {
+		parsed, err := Parse(test.layout, test.value, WithDialect(SimpleDateFormat))
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.layout, test.value, err)
+			continue
+		}
+		if result := Format(parsed, "uuuu-MM-dd HH:mm:ss.SSS", WithDialect(JavaTime)); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.layout, test.value, test.result, result)
+		}
+	}
+}
+
+func TestSimpleDateFormatWeeks(t *testing.T) {
+	// Weeks start on Sunday, and week 1 is the one holding January 1.
+	for _, test := range []struct {
+		date   string
+		result string
+	}{
+		{"2009-01-01", "2009-01 1 1"},
+		{"2010-12-31", "2011-01 5 5"},
+		{"2011-01-01", "2011-01 1 1"},
+		{"2011-01-02", "2011-02 1 2"},
+		{"2011-05-31", "2011-23 5 5"},
+		{"2012-12-29", "2012-52 5 5"},
+		{"2012-12-30", "2013-01 5 6"},
+	} {
+		tm, _ := time.Parse("2006-01-02", test.date)
+		if result := Format(tm, "YYYY-ww F W", WithDialect(SimpleDateFormat)); result != test.result {
+			t.Errorf("%s expected %q got %q", test.date, test.result, result)
+		}
+	}
+
+	for _, test := range []struct {
+		layout string
+		value  string
+		result string
+	}{
+		{"YYYY-ww", "2011-01", "2010-12-26"},
+		{"YYYY-ww-u", "2011-01-6", "2011-01-01"},
+		{"YYYY-ww EEE", "2011-02 Sun", "2011-01-02"},
+		{"YYYY-ww EEE", "2013-01 Mon", "2012-12-31"},
+		{"yyyy-MM W", "2011-01 2", "2011-01-02"},
+		{"yyyy-MM W u", "2011-01 1 6", "2011-01-01"},
+	} {
+		parsed, err := Parse(test.layout, test.value, WithDialect(SimpleDateFormat))
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.layout, test.value, err)
+		} else if result := parsed.Format("2006-01-02"); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.layout, test.value, test.result, result)
+		}
+	}
+}
+
+func TestSimpleDateFormatTwoDigitYear(t *testing.T) {
+	// Two-digit years are within 80 years before and 20 years after now.
+	now := time.Now().Year()
+	for _, years := range []int{-80, -79, -1, 0, 1, 19} {
+		year := now + years
+		value := Format(time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC), "yy", WithDialect(SimpleDateFormat))
+		parsed, err := Parse("yy", value, WithDialect(SimpleDateFormat))
+		if err != nil {
+			t.Errorf("%s error: %v", value, err)
+		} else if parsed.Year() != year {
+			t.Errorf("%s expected %d got %d", value, year, parsed.Year())
+		}
+	}
+}
+
+func TestTwoDigitYearStart(t *testing.T) {
+	for _, test := range []struct {
+		start int
+		value string
+		year  int
+	}{
+		{1950, "50", 1950}, {1950, "99", 1999}, {1950, "00", 2000}, {1950, "49", 2049},
+		{0, "00", 0}, {0, "99", 99},
+	} {
+		parsed, err := Parse("yy", test.value, WithDialect(SimpleDateFormat), WithTwoDigitYearStart(test.start))
+		if err != nil {
+			t.Errorf("%d %s error: %v", test.start, test.value, err)
+		} else if parsed.Year() != test.year {
+			t.Errorf("%d %s expected %d got %d", test.start, test.value, test.year, parsed.Year())
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package jodatime
+
+import (
+	"strconv"
+	"strings"
+)
+
+// An sqlToken is a layout element in the date formats of SQL databases,
+// empty for a database that has no equivalent. A leading "FM" turns off
+// padding for the element.
+type sqlToken struct {
+	std             int // canonical std value
+	postgres, mysql string
+	oracle, sqlite  string
+}
+
+// sqlTokens are the layout elements that mean the same in Joda layouts and
+// the date formats of SQL databases.
+var sqlTokens = []sqlToken{
+	{stdLongMonth, "FMMonth", "%M", "FMMonth", ""},
+	{stdMonth, "Mon", "%b", "Mon", ""},
+	{stdNumMonth | 1<<stdArgShift, "FMMM", "%c", "FMMM", ""},
+	{stdNumMonth | 2<<stdArgShift, "MM", "%m", "MM", "%m"},
+	{stdLongWeekDay, "FMDay", "%W", "FMDay", ""},
+	{stdWeekDay, "Dy", "%a", "Dy", ""},
+	{stdDay | 1<<stdArgShift, "FMDD", "%e", "FMDD", ""},
+	{stdDay | 2<<stdArgShift, "DD", "%d", "DD", "%d"},
+	{stdYearDay | 1<<stdArgShift, "FMDDD", "", "FMDDD", ""},
+	{stdYearDay | 3<<stdArgShift, "DDD", "%j", "DDD", "%j"},
+	{stdHour | 1<<stdArgShift, "FMHH24", "%k", "FMHH24", ""},
+	{stdHour | 2<<stdArgShift, "HH24", "%H", "HH24", "%H"},
+	{stdHour12 | 1<<stdArgShift, "FMHH12", "%l", "FMHH12", ""},
+	{stdHour12 | 2<<stdArgShift, "HH12", "%h", "HH12", ""},
+	{stdMinute | 1<<stdArgShift, "FMMI", "", "FMMI", ""},
+	{stdMinute | 2<<stdArgShift, "MI", "%i", "MI", "%M"},
+	{stdSecond | 1<<stdArgShift, "FMSS", "", "FMSS", ""},
+	{stdSecond | 2<<stdArgShift, "SS", "%s", "SS", "%S"},
+	{stdLongYear | 4<<stdArgShift, "YYYY", "%Y", "YYYY", "%Y"},
+	{stdYear | 2<<stdArgShift, "YY", "%y", "YY", ""},
+	{stdWeekYear | 4<<stdArgShift, "IYYY", "%x", "IYYY", ""},
+	{stdWeek | 1<<stdArgShift, "FMIW", "", "FMIW", ""},
+	{stdWeek | 2<<stdArgShift, "IW", "%v", "IW", ""},
+	{stdISOWeekDay | 1<<stdArgShift, "ID", "", "", ""},
+	{stdPM, "AM", "%p", "AM", ""},
+	{stdEra, "AD", "", "AD", ""},
+	// PostgreSQL's TZ only formats, and its OF is an offset.
+	{stdTZ, "", "", "TZD", ""},
+	{stdNumTZ, "TZHTZM", "", "TZHTZM", ""},
+	{stdNumColonTZ, "TZH:TZM", "", "TZH:TZM", ""},
+	{stdEpoch, "", "", "", "%s"},
+}
+
+// An sqlDialect is the date format language of an SQL database.
+type sqlDialect int
+
+const (
+	postgreSQL sqlDialect = iota
+	mySQL
+	oracle
+	sqlite
+)
+
+func (d sqlDialect) String() string {
+	switch d {
+	case postgreSQL:
+		return "PostgreSQL patterns"
+	case mySQL:
+		return "MySQL formats"
+	case oracle:
+		return "Oracle formats"
+	}
+	return "SQLite formats"
+}
+
+// token returns the element of t in the format language of d.
+func (d sqlDialect) token(t sqlToken) string {
+	switch d {
+	case postgreSQL:
+		return t.postgres
+	case mySQL:
+		return t.mysql
+	case oracle:
+		return t.oracle
+	}
+	return t.sqlite
+}
+
+// fraction returns the element for width digits of fractional seconds,
+// or "" if there is none.
+func (d sqlDialect) fraction(width int) string {
+	switch d {
+	case postgreSQL:
+		switch {
+		case width == 3:
+			return "MS"
+		case width == 6:
+			return "US"
+		case width < 6:
+			return "FF" + strconv.Itoa(width)
+		}
+	case mySQL:
+		if width == 6 {
+			return "%f"
+		}
+	case oracle:
+		if width <= 9 {
+			return "FF" + strconv.Itoa(width)
+		}
+	}
+	return ""
+}
+
+// appendLiteral appends text to b so that d copies it literally, and
+// returns the part of text it cannot copy, if any.
+func (d sqlDialect) appendLiteral(b []byte, text string) ([]byte, string) {
+	switch d {
+	case postgreSQL, oracle:
+		// Quote what may be taken for a pattern: letters in PostgreSQL,
+		// all but punctuation in Oracle.
+		plain := func(c byte) bool { return !isLetter(c) }
+		if d == oracle {
+			plain = func(c byte) bool { return strings.IndexByte(" -/,.;:", c) >= 0 }
+		}
+		for i := 0; i < len(text); {
+			j := i
+			for ; j < len(text) && plain(text[j]) == plain(text[i]); j++ {
+			}
+			run := text[i:j]
+			if d == oracle && strings.Contains(run, `"`) {
+				// Oracle has no way to quote a quote.
+				return b, `"`
+			}
+			run = strings.Replace(run, `"`, `\"`, -1)
+			if !plain(text[i]) {
+				run = `"` + run + `"`
+			}
+			b = append(b, run...)
+			i = j
+		}
+		return b, ""
+	}
+	return append(b, strings.Replace(text, "%", "%%", -1)...), ""
+}
+
+// ToPostgreSQLLayout translates a Joda layout into a pattern of PostgreSQL's
+// to_char and to_timestamp, such as "yyyy-MM-dd HH:mm:ss.SSS" into
+// "YYYY-MM-DD HH24:MI:SS.MS". Fractions of other than three or six digits
+// become FF1 to FF5, which need PostgreSQL 13. Elements PostgreSQL has no
+// equivalent for, such as Joda's K and zone names, are reported by a
+// *NoEquivalentError.
+func ToPostgreSQLLayout(joda string) (string, error) {
+	return toSQLLayout(joda, postgreSQL)
+}
+
+// ToMySQLLayout translates a Joda layout into a format of MySQL's
+// DATE_FORMAT and STR_TO_DATE, such as "yyyy-MM-dd HH:mm:ss" into
+// "%Y-%m-%d %H:%i:%s". MySQL has no time zones and fractions other than
+// microseconds; these and other elements MySQL has no equivalent for are
+// reported by a *NoEquivalentError.
+func ToMySQLLayout(joda string) (string, error) {
+	return toSQLLayout(joda, mySQL)
+}
+
+// ToOracleLayout translates a Joda layout into a format model of Oracle's
+// TO_CHAR and TO_TIMESTAMP, such as "d MMMM yyyy" into
+// "FMDD Month FMYYYY", switching fill mode where padding changes. Elements
+// Oracle has no equivalent for, and quotes in literal text, are reported by
+// a *NoEquivalentError.
+func ToOracleLayout(joda string) (string, error) {
+	return toSQLLayout(joda, oracle)
+}
+
+// ToSQLiteLayout translates a Joda layout into a format of SQLite's
+// strftime, such as "yyyy-MM-dd HH:mm:ss.SSS" into "%Y-%m-%d %H:%M:%f".
+// SQLite formats fractional seconds only as "ss.SSS", and has no names or
+// time zones; these and other elements SQLite has no equivalent for are
+// reported by a *NoEquivalentError.
+func ToSQLiteLayout(joda string) (string, error) {
+	return toSQLLayout(joda, sqlite)
+}
+
+func toSQLLayout(joda string, d sqlDialect) (string, error) {
+	var b []byte
+	fm := false // Oracle's fill mode
+	for layout := joda; layout != ""; {
+		prefix, std, suffix := nextStdChunk(layout)
+		var elem string
+		if b, elem = d.appendLiteral(b, prefix); elem != "" {
+			return "", &NoEquivalentError{joda, elem, d.String()}
+		}
+		if std == 0 {
+			break
+		}
+		elem = Joda.stdText(layout, suffix)
+		layout = suffix
+		var token string
+		switch s := std & stdMask; {
+		case s == stdFracSecond0 || s == stdFracSecond9:
+			token = d.fraction(std >> stdArgShift & stdWidthMask)
+		case d == sqlite && canonicalStd(std) == stdSecond|2<<stdArgShift &&
+			strings.HasPrefix(suffix, ".SSS") && !strings.HasPrefix(suffix, ".SSSS"):
+			token, layout = "%f", suffix[len(".SSS"):]
+		default:
+			token = lookupSQLToken(std, d)
+		}
+		if token == "" {
+			return "", &NoEquivalentError{joda, elem, d.String()}
+		}
+		if d == oracle {
+			// Unlike PostgreSQL's, Oracle's FM holds until the next FM.
+			need := strings.HasPrefix(token, "FM")
+			token = strings.TrimPrefix(token, "FM")
+			if need != fm {
+				b = append(b, "FM"...)
+				fm = need
+			}
+		}
+		b = append(b, token...)
+	}
+	return string(b), nil
+}
+
+// lookupSQLToken returns the element of the format language of d that is
+// equivalent to std.
+func lookupSQLToken(std int, d sqlDialect) string {
+	std = canonicalStd(std)
+	for _, t := range sqlTokens {
+		if t.std == std {
+			return d.token(t)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package jodatime_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/tengattack/jodatime"
+)
+
+func TestSQLLayout(t *testing.T) {
+	tests := []struct {
+		convert func(string) (string, error)
+		joda    string
+		result  string
+	}{
+		{ToPostgreSQLLayout, "yyyy-MM-dd HH:mm:ss.SSS", "YYYY-MM-DD HH24:MI:SS.MS"},
+		{ToPostgreSQLLayout, "yyyy-MM-dd'T'HH:mm:ss.SSSSSSZZ", `YYYY-MM-DD"T"HH24:MI:SS.USTZH:TZM`},
+		{ToPostgreSQLLayout, "EEEE, d MMMM yyyy h:mm a Z", `FMDay, FMDD FMMonth YYYY FMHH12:MI AM TZHTZM`},
+		{ToPostgreSQLLayout, "HH:mm:ss.S ss.SS ss.SSSSS", "HH24:MI:SS.FF1 SS.FF2 SS.FF5"},
+		{ToPostgreSQLLayout, "xxxx-'W'ww-e G", `IYYY-"W"IW-ID AD`},
+		{ToPostgreSQLLayout, `'at' "noon"`, `"at" \""noon"\"`},
+		{ToMySQLLayout, "yyyy-MM-dd HH:mm:ss.SSSSSS", "%Y-%m-%d %H:%i:%s.%f"},
+		{ToMySQLLayout, "EEE, d MMM yy h:mm a", "%a, %e %b %y %l:%i %p"},
+		{ToMySQLLayout, "xxxx-'W'ww '100%'", "%x-W%v 100%%"},
+		{ToOracleLayout, "yyyy-MM-dd'T'HH:mm:ss.SSSZZ", `YYYY-MM-DD"T"HH24:MI:SS.FF3TZH:TZM`},
+		{ToOracleLayout, "d MMMM yyyy", "FMDD Month FMYYYY"},
+		{ToOracleLayout, "EEE d/M H:mm ZZZ", "Dy FMDD/MM HH24:FMMI TZD"},
+		{ToSQLiteLayout, "yyyy-MM-dd HH:mm:ss.SSS", "%Y-%m-%d %H:%M:%f"},
+		{ToSQLiteLayout, "DDD X '%'", "%j %s %%"},
+	}
+	for _, test := range tests {
+		result, err := test.convert(test.joda)
+		if err != nil {
+			t.Errorf("%s error: %v", test.joda, err)
+		} else if result != test.result {
+			t.Errorf("%s expected %q got %q", test.joda, test.result, result)
+		}
+	}
+
+	errorTests := []struct {
+		convert func(string) (string, error)
+		joda    string
+		elem    string
+	}{
+		{ToPostgreSQLLayout, "HH:mm K", "K"},
+		{ToPostgreSQLLayout, "ss.SSSSSSS", "SSSSSSS"},
+		{ToPostgreSQLLayout, "HH:mm ZZZ", "ZZZ"},
+		{ToMySQLLayout, "HH:mm Z", "Z"},
+		{ToMySQLLayout, "HH:m", "m"},
+		{ToMySQLLayout, "ss.SSS", "SSS"},
+		{ToOracleLayout, "yyyy e", "e"},
+		{ToOracleLayout, `yyyy '"'`, `"`},
+		{ToSQLiteLayout, "MMM", "MMM"},
+		{ToSQLiteLayout, "ss.SSSSSS", "SSSSSS"},
+		{ToSQLiteLayout, "HH:mm ZZ", "ZZ"},
+	}
+	for _, test := range errorTests {
+		_, err := test.convert(test.joda)
+		var e *NoEquivalentError
+		if !errors.As(err, &e) {
+			t.Errorf("%s expected NoEquivalentError got %v", test.joda, err)
+		} else if e.Elem != test.elem {
+			t.Errorf("%s expected element %q got %q", test.joda, test.elem, e.Elem)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package jodatime
+
+// strftimeComposites are the directives that stand for other directives,
+// in the C locale.
+var strftimeComposites = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'D': "%m/%d/%y",
+	'F': "%Y-%m-%d",
+	'r': "%I:%M:%S %p",
+	'R': "%H:%M",
+	'T': "%H:%M:%S",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+	'n': "\n",
+	't': "\t",
+}
+
+// expandStrftime replaces the composite directives of layout, such as
+// "%F", by the directives they stand for.
+func expandStrftime(layout string) string {
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			continue
+		}
+		_, _, _, j := strftimeDirective(layout, i)
+		if j >= len(layout) {
+			break
+		}
+		if s, ok := strftimeComposites[layout[j]]; ok {
+			layout = layout[:i] + s + layout[j+1:]
+			i--
+			continue
+		}
+		i = j
+	}
+	return layout
+}
+
+// strftimeDirective parses the directive starting with the percent sign at
+// layout[i]: an optional flag, field width, colons and E or O modifier,
+// and returns them with the index of the conversion character.
+func strftimeDirective(layout string, i int) (flag byte, width, colons, j int) {
+	j = i + 1
+	if j < len(layout) && (layout[j] == '-' || layout[j] == '_' || layout[j] == '0' || layout[j] == '^' || layout[j] == '#') {
+		flag = layout[j]
+		j++
+	}
+	for ; isDigit(layout, j); j++ {
+		width = width*10 + int(layout[j]-'0')
+	}
+	for ; j < len(layout) && layout[j] == ':'; j++ {
+		colons++
+	}
+	if j+1 < len(layout) && (layout[j] == 'E' || layout[j] == 'O') {
+		j++
+	}
+	return flag, width, colons, j
+}
+
+// nextStrftimeChunk finds the first occurrence of a std string of the
+// strftime dialect in layout, whose composite directives are expanded,
+// and returns the text before, the std string, and the text after.
+// Everything but a directive is literal text.
+func nextStrftimeChunk(layout string) (prefix string, std int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			continue
+		}
+		flag, width, colons, j := strftimeDirective(layout, i)
+		if j >= len(layout) {
+			break
+		}
+		if layout[j] == '%' {
+			// Keep the second percent sign as text.
+			layout = layout[:i] + layout[j:]
+			continue
+		}
+		if std := strftimeStd(layout[j], flag, width, colons); std != 0 {
+			return layout[0:i], std, layout[j+1:]
+		}
+	}
+	return layout, 0, ""
+}
+
+// strftimeStd returns the std value of the conversion character c with
+// the given flag, field width and number of colons.
+func strftimeStd(c, flag byte, width, colons int) int {
+	std, pad := 0, 2
+	switch c {
+	case 'a':
+		return stdWeekDay
+	case 'A':
+		return stdLongWeekDay
+	case 'b', 'h':
+		return stdMonth
+	case 'B':
+		return stdLongMonth
+	case 'p':
+		return stdPM
+	case 'P':
+		return stdpm
+	case 'y':
+		return stdYear | 2<<stdArgShift
+	case 'g':
+		return stdWeekYear | 2<<stdArgShift
+	case 'Z':
+		return stdTZ
+	case 's':
+		return stdEpoch
+	case 'Q':
+		return stdEpoch | 3<<stdArgShift
+	case 'z':
+		switch colons {
+		case 0:
+			return stdNumTZ | stdZeroZ
+		case 1:
+			return stdNumColonTZ | stdZeroZ
+		}
+		return stdNumColonSecondsTZ | stdZeroZ
+	case 'f', 'L', 'N':
+		// Microseconds, milliseconds and nanoseconds, or as many digits
+		// as the field width.
+		digits := 9
+		switch c {
+		case 'f':
+			digits = 6
+		case 'L':
+			digits = 3
+		}
+		if width > 0 && width <= 9 {
+			digits = width
+		}
+		return stdFracSecond0 | digits<<stdArgShift | stdVarDigits
+	case 'd':
+		std = stdDay
+	case 'e':
+		std = stdDay
+		if flag == 0 {
+			flag = '_'
+		}
+	case 'm':
+		std = stdNumMonth
+	case 'H':
+		std = stdHour
+	case 'k':
+		std = stdHour
+		if flag == 0 {
+			flag = '_'
+		}
+	case 'I':
+		std = stdHour12
+	case 'l':
+		std = stdHour12
+		if flag == 0 {
+			flag = '_'
+		}
+	case 'M':
+		std = stdMinute
+	case 'S':
+		std = stdSecond
+	case 'j':
+		std, pad = stdYearDay, 3
+	case 'Y':
+		std, pad = stdLongYear, 4
+	case 'G':
+		std, pad = stdWeekYear, 4
+	case 'V':
+		std = stdWeek
+	case 'U':
+		std = stdSundayWeek
+	case 'W':
+		std = stdMondayWeek
+	case 'u':
+		std, pad = stdISOWeekDay, 1
+	case 'w':
+		std, pad = stdNumWeekDay, 1
+	default:
+		return 0
+	}
+	if width > 0 {
+		pad = width
+	}
+	switch flag {
+	case '-':
+		pad = 1
+	case '_':
+		std |= stdSpacePad
+	}
+	return std | pad<<stdArgShift
+}
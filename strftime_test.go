@@ -0,0 +1,67 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+var strftimeTests = []FormatTest{
+	{"ISO 8601", "%Y-%m-%dT%H:%M:%S.%f%z", "2009-02-04T21:00:57.012345-0800"},
+	{"names", "%a %A %b %h %B", "Wed Wednesday Feb Feb February"},
+	{"padding", "%e|%-d|%_m|%k|%l|%-I%p|%P", " 4|4| 2|21| 9|9PM|pm"},
+	{"weeks", "%j %U %W %V %G %g %u %w", "035 05 05 06 2009 09 3 3"},
+	{"fractions", "%L %N %3N", "012 012345600 012"},
+	{"epoch", "%s %Q", "1233810057 1233810057012"},
+	{"zones", "%z %:z %::z %Z", "-0800 -08:00 -08:00:00 PST"},
+	{"composites", "%F %T %D %R %r", "2009-02-04 21:00:57 02/04/09 21:00 09:00:57 PM"},
+	{"locale", "%c|%x|%X", "Wed Feb  4 21:00:57 2009|02/04/09|21:00:57"},
+	{"literals", "%% %n%t 100%%", "% \n\t 100%"},
+	{"letters", "Year %Y", "Year 2009"},
+	{"unknown directive", "%q", "%q"},
+}
+
+func TestStrftimeFormat(t *testing.T) {
+	tm := time.Unix(0, 1233810057012345600).In(local)
+	for _, test := range strftimeTests {
+		result := Format(tm, test.format, WithDialect(Strftime))
+		if result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+}
+
+func TestStrftimeParse(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  string
+		result string
+	}{
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2009-02-04T21:00:57.012345-0800", "2009-02-05T05:00:57.012345+00:00"},
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2009-02-04T21:00:57.5Z", "2009-02-04T21:00:57.500000+00:00"},
+		{"%Y-%m-%dT%H:%M:%S%z", "2009-02-04T21:00:57+05:30", "2009-02-04T15:30:57.000000+00:00"},
+		{"%Y%m%d%H%M%S", "20090204210057", "2009-02-04T21:00:57.000000+00:00"},
+		{"%d/%m/%y %I:%M %p", "4/2/09 9:00 PM", "2009-02-04T21:00:00.000000+00:00"},
+		{"%a %b %e %H:%M:%S %Y", "Wed Feb  4 21:00:57 2009", "2009-02-04T21:00:57.000000+00:00"},
+		{"%c", "Wed Feb  4 21:00:57 2009", "2009-02-04T21:00:57.000000+00:00"},
+		{"%Y-%j", "2009-035", "2009-02-04T00:00:00.000000+00:00"},
+		{"%G-W%V-%u", "2009-W06-3", "2009-02-04T00:00:00.000000+00:00"},
+		{"%Y %U %a", "2009 05 Wed", "2009-02-04T00:00:00.000000+00:00"},
+		{"%Y %W %w", "2009 05 3", "2009-02-04T00:00:00.000000+00:00"},
+		{"%Y %U", "2009 00", "2008-12-28T00:00:00.000000+00:00"},
+		{"%s", "1233810057", "2009-02-05T05:00:57.000000+00:00"},
+		{"%s.%3N", "1233810057.012", "2009-02-05T05:00:57.012000+00:00"},
+		{"100%% %F", "100% 2009-02-04", "2009-02-04T00:00:00.000000+00:00"},
+	}
+	for _, test := range tests {
+		tm, err := Parse(test.layout, test.value, WithDialect(Strftime))
+		if err != nil {
+			t.Errorf("%s %q error: %v", test.layout, test.value, err)
+			continue
+		}
+		if result := Format(tm.UTC(), "%Y-%m-%dT%H:%M:%S.%f%:z", WithDialect(Strftime)); result != test.result {
+			t.Errorf("%s %q expected %q got %q", test.layout, test.value, test.result, result)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package jodatime
+
+// A Zone is a time zone an abbreviation such as "PST" may stand for.
+// With a Name and HasOffset, the abbreviation is Offset in that location,
+// as "EDT" is -04:00 in America/New_York even in winter; with a Name only,
+// it is whatever offset is in effect there, as java.time short IDs are.
+// Without a Name it is the fixed Offset.
+type Zone struct {
+	Name      string // IANA location name, empty for a fixed offset
+	Offset    int    // seconds east of UTC
+	HasOffset bool   // Offset holds in Name rather than Name's own offset
+}
+
+// A ZoneResolver maps a time zone abbreviation to the zones it may stand
+// for, most likely first. An unknown abbreviation yields no zones, in which
+// case the abbreviation itself is tried as a location name.
+type ZoneResolver interface {
+	ResolveZone(abbr string) []Zone
+}
+
+// ZoneMap is a ZoneResolver backed by a map from abbreviation to zones.
+type ZoneMap map[string][]Zone
+
+// ResolveZone implements ZoneResolver.
+func (m ZoneMap) ResolveZone(abbr string) []Zone {
+	return m[abbr]
+}
+
+// ZoneResolvers combines several resolvers. The zones returned by each of
+// them are concatenated in order, so an abbreviation that the resolvers
+// disagree on is ambiguous.
+type ZoneResolvers []ZoneResolver
+
+// ResolveZone implements ZoneResolver.
+func (rs ZoneResolvers) ResolveZone(abbr string) []Zone {
+	var zones []Zone
+	for _, r := range rs {
+	next:
+		for _, z := range r.ResolveZone(abbr) {
+			for _, seen := range zones {
+				if seen == z {
+					continue next
+				}
+			}
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// JavaShortIDs is the zone id map of java.time.ZoneId.SHORT_IDS.
+// https://docs.oracle.com/javase/8/docs/api/java/time/ZoneId.html
+var JavaShortIDs = ZoneMap{
+	"EST": {{"", -18000, true}},
+	"HST": {{"", -36000, true}},
+	"MST": {{"", -25200, true}},
+	"ACT": {{"Australia/Darwin", 0, false}},
+	"AET": {{"Australia/Sydney", 0, false}},
+	"AGT": {{"America/Argentina/Buenos_Aires", 0, false}},
+	"ART": {{"Africa/Cairo", 0, false}},
+	"AST": {{"America/Anchorage", 0, false}},
+	"BET": {{"America/Sao_Paulo", 0, false}},
+	"BST": {{"Asia/Dhaka", 0, false}},
+	"CAT": {{"Africa/Harare", 0, false}},
+	"CNT": {{"America/St_Johns", 0, false}},
+	"CST": {{"America/Chicago", 0, false}},
+	"CTT": {{"Asia/Shanghai", 0, false}},
+	"EAT": {{"Africa/Addis_Ababa", 0, false}},
+	"ECT": {{"Europe/Paris", 0, false}},
+	"IET": {{"America/Indiana/Indianapolis", 0, false}},
+	"IST": {{"Asia/Kolkata", 0, false}},
+	"JST": {{"Asia/Tokyo", 0, false}},
+	"MIT": {{"Pacific/Apia", 0, false}},
+	"NET": {{"Asia/Yerevan", 0, false}},
+	"NST": {{"Pacific/Auckland", 0, false}},
+	"PLT": {{"Asia/Karachi", 0, false}},
+	"PNT": {{"America/Phoenix", 0, false}},
+	"PRT": {{"America/Puerto_Rico", 0, false}},
+	"PST": {{"America/Los_Angeles", 0, false}},
+	"SST": {{"Pacific/Guadalcanal", 0, false}},
+	"VST": {{"Asia/Ho_Chi_Minh", 0, false}},
+}
+
+// NorthAmerica maps the abbreviations used in the United States and Canada.
+var NorthAmerica = ZoneMap{
+	"NST":  {{"America/St_Johns", -12600, true}},
+	"NDT":  {{"America/St_Johns", -9000, true}},
+	"AST":  {{"America/Halifax", -14400, true}},
+	"ADT":  {{"America/Halifax", -10800, true}},
+	"EST":  {{"America/New_York", -18000, true}},
+	"EDT":  {{"America/New_York", -14400, true}},
+	"CST":  {{"America/Chicago", -21600, true}},
+	"CDT":  {{"America/Chicago", -18000, true}},
+	"MST":  {{"America/Denver", -25200, true}},
+	"MDT":  {{"America/Denver", -21600, true}},
+	"PST":  {{"America/Los_Angeles", -28800, true}},
+	"PDT":  {{"America/Los_Angeles", -25200, true}},
+	"AKST": {{"America/Anchorage", -32400, true}},
+	"AKDT": {{"America/Anchorage", -28800, true}},
+	"HST":  {{"Pacific/Honolulu", -36000, true}},
+	"HDT":  {{"America/Adak", -32400, true}},
+}
+
+// Europe maps the abbreviations used in Europe.
+var Europe = ZoneMap{
+	"BST":  {{"Europe/London", 3600, true}},
+	"WET":  {{"Europe/Lisbon", 0, true}},
+	"WEST": {{"Europe/Lisbon", 3600, true}},
+	"CET":  {{"Europe/Paris", 3600, true}},
+	"CEST": {{"Europe/Paris", 7200, true}},
+	"EET":  {{"Europe/Athens", 7200, true}},
+	"EEST": {{"Europe/Athens", 10800, true}},
+	"MSK":  {{"Europe/Moscow", 10800, true}},
+}
+
+// AsiaPacific maps the abbreviations used in Asia and Oceania.
+var AsiaPacific = ZoneMap{
+	"CST":  {{"Asia/Shanghai", 28800, true}},
+	"HKT":  {{"Asia/Hong_Kong", 28800, true}},
+	"JST":  {{"Asia/Tokyo", 32400, true}},
+	"KST":  {{"Asia/Seoul", 32400, true}},
+	"IST":  {{"Asia/Kolkata", 19800, true}},
+	"PKT":  {{"Asia/Karachi", 18000, true}},
+	"NPT":  {{"Asia/Kathmandu", 20700, true}},
+	"ICT":  {{"Asia/Bangkok", 25200, true}},
+	"WIB":  {{"Asia/Jakarta", 25200, true}},
+	"WITA": {{"Asia/Makassar", 28800, true}},
+	"WIT":  {{"Asia/Jayapura", 32400, true}},
+	"SGT":  {{"Asia/Singapore", 28800, true}},
+	"MYT":  {{"Asia/Kuala_Lumpur", 28800, true}},
+	"PHT":  {{"Asia/Manila", 28800, true}},
+	"ChST": {{"Pacific/Guam", 36000, true}},
+	"AWST": {{"Australia/Perth", 28800, true}},
+	"ACST": {{"Australia/Adelaide", 34200, true}},
+	"ACDT": {{"Australia/Adelaide", 37800, true}},
+	"AEST": {{"Australia/Sydney", 36000, true}},
+	"AEDT": {{"Australia/Sydney", 39600, true}},
+	"NZST": {{"Pacific/Auckland", 43200, true}},
+	"NZDT": {{"Pacific/Auckland", 46800, true}},
+}
+
+// Abbreviations maps the standard and daylight saving abbreviations of
+// NorthAmerica, Europe and AsiaPacific. Where they disagree the earlier one
+// wins, so "CST" is Central Standard Time rather than China Standard Time.
+var Abbreviations = mergeZones(NorthAmerica, Europe, AsiaPacific)
+
+// defaultZones is used when no ZoneResolver is configured: JavaShortIDs,
+// then the abbreviations of Abbreviations it lacks, such as "EDT" and
+// "CEST".
+var defaultZones = ZoneResolvers{JavaShortIDs, zonesNotIn(Abbreviations, JavaShortIDs)}
+
+// mergeZones returns a ZoneMap with the entries of all maps,
+// the first map that has an abbreviation winning.
+func mergeZones(maps ...ZoneMap) ZoneMap {
+	m := ZoneMap{}
+	for _, zones := range maps {
+		for abbr, z := range zones {
+			if _, ok := m[abbr]; !ok {
+				m[abbr] = z
+			}
+		}
+	}
+	return m
+}
+
+// zonesNotIn returns a ZoneMap with the entries of m whose abbreviation
+// other does not have.
+func zonesNotIn(m, other ZoneMap) ZoneMap {
+	rest := ZoneMap{}
+	for abbr, zones := range m {
+		if _, ok := other[abbr]; !ok {
+			rest[abbr] = zones
+		}
+	}
+	return rest
+}
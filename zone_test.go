@@ -0,0 +1,155 @@
+package jodatime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/tengattack/jodatime"
+)
+
+type ZoneTest struct {
+	name     string
+	resolver ZoneResolver
+	value    string
+	result   string // RFC3339, empty for an error
+}
+
+var zoneTests = []ZoneTest{
+	{"default CST", nil, "2010-02-04 21:00:57 CST", "2010-02-04T21:00:57-06:00"},
+	{"default PST in summer", nil, "2010-07-04 21:00:57 PST", "2010-07-04T21:00:57-07:00"},
+	{"default BST", nil, "2010-07-04 21:00:57 BST", "2010-07-04T21:00:57+06:00"},
+	{"default AST", nil, "2010-02-04 21:00:57 AST", "2010-02-04T21:00:57-09:00"},
+	{"default NST", nil, "2010-07-04 21:00:57 NST", "2010-07-04T21:00:57+12:00"},
+	{"default EDT", nil, "2010-07-04 21:00:57 EDT", "2010-07-04T21:00:57-04:00"},
+	{"default EDT in winter", nil, "2010-02-04 22:00:57 EDT", "2010-02-04T21:00:57-05:00"},
+	{"default CEST", nil, "2010-07-04 21:00:57 CEST", "2010-07-04T21:00:57+02:00"},
+	{"abbreviations PST in summer", Abbreviations, "2010-07-04 21:00:57 PST", "2010-07-04T22:00:57-07:00"},
+	{"abbreviations PDT in winter", Abbreviations, "2010-02-04 22:00:57 PDT", "2010-02-04T21:00:57-08:00"},
+	{"abbreviations EDT", Abbreviations, "2010-07-04 21:00:57 EDT", "2010-07-04T21:00:57-04:00"},
+	{"abbreviations CDT", Abbreviations, "2010-07-04 21:00:57 CDT", "2010-07-04T21:00:57-05:00"},
+	{"abbreviations MDT", Abbreviations, "2010-07-04 21:00:57 MDT", "2010-07-04T21:00:57-06:00"},
+	{"abbreviations BST", Abbreviations, "2010-07-04 21:00:57 BST", "2010-07-04T21:00:57+01:00"},
+	{"abbreviations CEST", Abbreviations, "2010-07-04 21:00:57 CEST", "2010-07-04T21:00:57+02:00"},
+	{"abbreviations CET in summer", Abbreviations, "2010-07-04 21:00:57 CET", "2010-07-04T22:00:57+02:00"},
+	{"abbreviations AEDT", Abbreviations, "2010-02-04 21:00:57 AEDT", "2010-02-04T21:00:57+11:00"},
+	{"abbreviations WET in summer", Abbreviations, "2010-07-04 21:00:57 WET", "2010-07-04T22:00:57+01:00"},
+	{"offset in a location", ZoneMap{"CDT": {{"Asia/Shanghai", 9 * 3600, true}}}, "2010-02-04 21:00:57 CDT", "2010-02-04T20:00:57+08:00"},
+	{"zero offset in a location", ZoneMap{"UKT": {{"Europe/London", 0, true}}}, "2010-07-04 21:00:57 UKT", "2010-07-04T22:00:57+01:00"},
+	{"java PST in summer", JavaShortIDs, "2010-07-04 21:00:57 PST", "2010-07-04T21:00:57-07:00"},
+	{"java IST", JavaShortIDs, "2010-02-04 21:00:57 IST", "2010-02-04T21:00:57+05:30"},
+	{"java EST", JavaShortIDs, "2010-02-04 21:00:57 EST", "2010-02-04T18:00:57-08:00"},
+	{"north america CST", NorthAmerica, "2010-02-04 21:00:57 CST", "2010-02-04T21:00:57-06:00"},
+	{"asia pacific CST", AsiaPacific, "2010-02-04 21:00:57 CST", "2010-02-04T21:00:57+08:00"},
+	{"combined CST", ZoneResolvers{AsiaPacific, NorthAmerica}, "2010-02-04 21:00:57 CST", "2010-02-04T21:00:57+08:00"},
+	{"custom", ZoneMap{"XST": {{Name: "Asia/Tokyo"}}}, "2010-02-04 21:00:57 XST", "2010-02-04T21:00:57+09:00"},
+}
+
+func TestParseZone(t *testing.T) {
+	for _, test := range zoneTests {
+		f := NewFormatter("YYYY-MM-dd HH:mm:ss ZZZ")
+		if test.resolver != nil {
+			f = f.With(WithZoneResolver(test.resolver))
+		}
+		time, err := f.Parse(test.value)
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+		} else if result := Format(time, RFC3339); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	_, err := Parse("YYYY-MM-dd HH:mm:ss ZZZ", "2010-02-04 21:00:57 CST",
+		WithZoneResolver(ZoneResolvers{NorthAmerica, AsiaPacific}), RejectAmbiguousZones())
+	if err == nil {
+		t.Errorf("ambiguous CST expected error")
+	}
+}
+
+func TestParseOffsetZone(t *testing.T) {
+	const layout = "YYYY-MM-dd HH:mm:ssZZ"
+	tm, err := Parse(layout, "2010-02-04 21:00:57-08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Location() != time.Local {
+		t.Errorf("matching offset expected Local got %v", tm.Location())
+	}
+	tm, err = Parse(layout, "2010-02-04 21:00:57+00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Location() != time.UTC {
+		t.Errorf("zero offset expected UTC got %v", tm.Location())
+	}
+	tm, err = Parse(layout, "2010-02-04 21:00:57-08:00", KeepFixedZone())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := tm.Zone(); tm.Location() == time.Local || offset != -28800 {
+		t.Errorf("KeepFixedZone expected fixed -08:00 got %v", tm.Location())
+	}
+
+	// An abbreviation no resolver knows must match too.
+	for value, name := range map[string]string{
+		"2010-02-04 21:00:57-08:00 PST": time.Local.String(),
+		"2010-02-04 21:00:57-08:00 XST": "XST",
+		"2010-02-04 21:00:57+00:00 UTC": "UTC",
+		"2010-02-04 21:00:57+00:00 GMT": "GMT",
+	} {
+		tm, err = Parse(layout+" ZZZ", value, WithZoneResolver(ZoneResolvers{}))
+		if err != nil {
+			t.Errorf("%q error: %v", value, err)
+		} else if tm.Location().String() != name {
+			t.Errorf("%q expected %s got %v", value, name, tm.Location())
+		}
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	tm, err = ParseInLocation(layout, "2010-02-04 21:00:57+09:00", tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Location() != tokyo {
+		t.Errorf("ParseInLocation expected %v got %v", tokyo, tm.Location())
+	}
+}
+
+func TestParseWithZone(t *testing.T) {
+	const layout = "YYYY-MM-dd HH:mm:ssZZ"
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	tests := []struct {
+		name   string
+		value  string
+		opts   []Option
+		result string
+	}{
+		{"convert", "2010-02-04 21:00:57-08:00", []Option{WithZone(time.UTC)}, "2010-02-05T05:00:57+00:00"},
+		{"convert tokyo", "2010-02-04 21:00:57-08:00", []Option{WithZone(tokyo)}, "2010-02-05T14:00:57+09:00"},
+		{"offset parsed", "2010-02-04 21:00:57-08:00", []Option{WithZone(tokyo), WithOffsetParsed()}, "2010-02-04T21:00:57-08:00"},
+		{"zone after offset parsed", "2010-02-04 21:00:57-08:00", []Option{WithOffsetParsed(), WithZone(tokyo)}, "2010-02-05T14:00:57+09:00"},
+	}
+	for _, test := range tests {
+		tm, err := Parse(layout, test.value, test.opts...)
+		if err != nil {
+			t.Errorf("%s error: %v", test.name, err)
+		} else if result := Format(tm, RFC3339); result != test.result {
+			t.Errorf("%s expected %q got %q", test.name, test.result, result)
+		}
+	}
+
+	tm, err := Parse("YYYY-MM-dd HH:mm:ss", "2010-02-04 21:00:57", WithZone(tokyo), WithOffsetParsed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := Format(tm, RFC3339); result != "2010-02-04T21:00:57+09:00" {
+		t.Errorf("no zone expected %q got %q", "2010-02-04T21:00:57+09:00", result)
+	}
+
+	tm = time.Unix(0, 1233810057012345600)
+	if result := Format(tm, RFC3339, WithZone(tokyo)); result != "2009-02-05T14:00:57+09:00" {
+		t.Errorf("Format WithZone expected %q got %q", "2009-02-05T14:00:57+09:00", result)
+	}
+	if result := NewFormatter(RFC3339, WithZone(time.UTC)).Format(tm); result != "2009-02-05T05:00:57+00:00" {
+		t.Errorf("Formatter WithZone expected %q got %q", "2009-02-05T05:00:57+00:00", result)
+	}
+}